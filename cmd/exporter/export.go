@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"syscall"
+
+	"cosmos-evm-exporter/internal/blockchain"
+	"cosmos-evm-exporter/internal/export"
+	"cosmos-evm-exporter/internal/metrics"
+	"cosmos-evm-exporter/internal/proposals"
+)
+
+// runExport writes proposal records for a height or time range as CSV or
+// Parquet. Records come from the store, a running exporter's history API, or
+// a one-off backfill against the configured RPC endpoints.
+func runExport(args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	configFile := flags.String("config", "", "Path to config file")
+	dbPath := flags.String("db", "", "Path to the store file (overrides store_path)")
+	apiURL := flags.String("url", "", "Base URL of a running exporter, e.g. http://localhost:2113")
+	backfill := flags.Bool("backfill", false, "Process --from..--to from the RPC endpoints instead of reading recorded proposals")
+	validator := flags.String("validator", "", "Only include this validator")
+	from := flags.String("from", "", "Start consensus height or RFC3339 time")
+	to := flags.String("to", "", "End consensus height or RFC3339 time")
+	format := flags.String("format", export.FormatCSV, "Output format: csv or parquet")
+	output := flags.String("output", "", "Output file (default stdout, required for parquet)")
+	flags.Parse(args)
+
+	if *format == export.FormatParquet && *output == "" {
+		fmt.Println("--output is required for parquet exports")
+		os.Exit(2)
+	}
+
+	query := url.Values{}
+	for key, value := range map[string]string{"validator": *validator, "from": *from, "to": *to} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	var records []proposals.Record
+	var err error
+	if *backfill {
+		var memory *proposals.MemoryStore
+		memory, err = backfillStore(*configFile, *from, *to, *output != "")
+		if err == nil {
+			records, _, err = memory.Query(proposals.Filter{})
+		}
+	} else {
+		records, err = loadRecords(*configFile, *dbPath, *apiURL, query)
+	}
+	if err != nil {
+		fmt.Printf("Failed to load proposals: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Exports read naturally in chain order
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].CLHeight < records[j].CLHeight
+	})
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			fmt.Printf("Failed to create output file: %v\n", err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if err := export.Write(w, *format, records); err != nil {
+		fmt.Printf("Failed to write export: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// backfillStore runs the block processor over the consensus heights
+// [from, to] and returns the store holding the recorded proposals and
+// signatures.
+func backfillStore(configFile, from, to string, logToStdout bool) (*proposals.MemoryStore, error) {
+	if configFile == "" {
+		return nil, fmt.Errorf("--backfill requires --config")
+	}
+
+	fromHeight, err := strconv.ParseInt(from, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("--backfill requires --from to be a height: %w", err)
+	}
+	toHeight, err := strconv.ParseInt(to, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("--backfill requires --to to be a height: %w", err)
+	}
+	if toHeight < fromHeight {
+		return nil, fmt.Errorf("--to %d is below --from %d", toHeight, fromHeight)
+	}
+
+	cfg := mustLoadConfig(configFile)
+	// Keep log lines out of an export written to stdout
+	cfg.EnableStdout = cfg.EnableStdout && logToStdout
+	log := newLogger(cfg)
+	defer log.Close()
+
+	processor, err := blockchain.NewBlockProcessor(cfg, metrics.NewBlockMetrics(), log)
+	if err != nil {
+		return nil, err
+	}
+
+	memory := proposals.NewMemoryStore(int(toHeight - fromHeight + 1))
+	processor.SetProposalStore(memory)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	failed, err := processor.Backfill(ctx, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+	if len(failed) > 0 {
+		// Stdout may carry the export itself
+		fmt.Fprintf(os.Stderr, "Warning: %d heights could not be fetched and are missing from the results: %v\n", len(failed), failed)
+	}
+
+	return memory, nil
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"cosmos-evm-exporter/internal/blockchain"
+	"cosmos-evm-exporter/internal/metrics"
+)
+
+// runInspect prints what the exporter sees at one consensus height: the
+// block, its decoded transactions and the execution block matched to it.
+func runInspect(args []string) {
+	flags := flag.NewFlagSet("inspect", flag.ExitOnError)
+	configFile := flags.String("config", "config.yaml", "Path to config file")
+	height := flags.Int64("height", 0, "Consensus height to inspect")
+	format := flags.String("format", "text", "Output format: text or json")
+	flags.Parse(args)
+
+	if *height <= 0 {
+		fmt.Println("--height is required")
+		os.Exit(2)
+	}
+	if *format != "text" && *format != "json" {
+		fmt.Printf("Unknown format %q\n", *format)
+		os.Exit(2)
+	}
+
+	cfg := mustLoadConfig(*configFile)
+	// Keep log lines out of the breakdown
+	cfg.EnableStdout = false
+	log := newLogger(cfg)
+	defer log.Close()
+
+	processor, err := blockchain.NewBlockProcessor(cfg, metrics.NewBlockMetrics(), log)
+	if err != nil {
+		fmt.Printf("Failed to create block processor: %v\n", err)
+		os.Exit(1)
+	}
+
+	inspection, err := processor.Inspect(*height)
+	if err != nil {
+		fmt.Printf("Failed to inspect height %d: %v\n", *height, err)
+		os.Exit(1)
+	}
+
+	switch *format {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(inspection)
+	case "text":
+		writeInspection(os.Stdout, inspection)
+	}
+}
+
+func writeInspection(w io.Writer, in *blockchain.Inspection) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Consensus block\n")
+	fmt.Fprintf(tw, "  height\t%d\n", in.Height)
+	fmt.Fprintf(tw, "  hash\t%s\n", in.Hash)
+	fmt.Fprintf(tw, "  time\t%s\n", in.Time.UTC().Format("2006-01-02T15:04:05Z"))
+	fmt.Fprintf(tw, "  proposer\t%s (ours: %t)\n", in.Proposer, in.Ours)
+	fmt.Fprintf(tw, "  txs\t%d\n", len(in.Txs))
+
+	fmt.Fprintf(tw, "\nTransactions\n")
+	for _, tx := range in.Txs {
+		switch {
+		case tx.Error != "":
+			fmt.Fprintf(tw, "  #%d\tmsg_type %d\terror: %s\n", tx.Index, tx.MsgType, tx.Error)
+		default:
+			fmt.Fprintf(tw, "  #%d\tmsg_type %d\t%s (%d bytes)\n", tx.Index, tx.MsgType, tx.Type, tx.Length)
+		}
+	}
+
+	if p := in.Payload; p != nil {
+		fmt.Fprintf(tw, "\nExecution payload\n")
+		fmt.Fprintf(tw, "  number\t%d\n", p.Number)
+		fmt.Fprintf(tw, "  block_hash\t%s\n", p.BlockHash)
+		fmt.Fprintf(tw, "  parent_hash\t%s\n", p.ParentHash)
+		fmt.Fprintf(tw, "  state_root\t%s\n", p.StateRoot)
+		fmt.Fprintf(tw, "  fee_recipient\t%s\n", p.FeeRecipient)
+		fmt.Fprintf(tw, "  gas\t%d / %d\n", p.GasUsed, p.GasLimit)
+		fmt.Fprintf(tw, "  txs\t%d\n", p.Txs)
+	}
+
+	fmt.Fprintf(tw, "\nExecution block\n")
+	fmt.Fprintf(tw, "  gap\t%d\n", in.Gap)
+	fmt.Fprintf(tw, "  expected_height\t%d (scanned %d..%d)\n", in.ExpectedELHeight, in.ScanStart, in.ScanEnd)
+	if b := in.ELBlock; b != nil {
+		fmt.Fprintf(tw, "  number\t%d (matched: %t)\n", b.Number, b.Matched)
+		fmt.Fprintf(tw, "  hash\t%s\n", b.Hash)
+		fmt.Fprintf(tw, "  parent_hash\t%s\n", b.ParentHash)
+		fmt.Fprintf(tw, "  coinbase\t%s\n", b.Coinbase)
+		fmt.Fprintf(tw, "  time\t%s\n", b.Time.Format("2006-01-02T15:04:05Z"))
+		fmt.Fprintf(tw, "  gas\t%d / %d\n", b.GasUsed, b.GasLimit)
+		fmt.Fprintf(tw, "  base_fee\t%s\n", b.BaseFee)
+		fmt.Fprintf(tw, "  txs\t%d\n", b.Txs)
+		if b.Builder != "" {
+			fmt.Fprintf(tw, "  builder\t%s\n", b.Builder)
+		}
+		if b.PaymentWei != "" {
+			fmt.Fprintf(tw, "  builder_payment_wei\t%s\n", b.PaymentWei)
+		}
+	} else {
+		fmt.Fprintf(tw, "  block\tunavailable\n")
+	}
+
+	if len(in.Mismatches) > 0 {
+		fmt.Fprintf(tw, "  payload_mismatches\t%s\n", strings.Join(in.Mismatches, ", "))
+	}
+
+	fmt.Fprintf(tw, "\nVerdict\t%s\n", in.Verdict)
+	if in.MissReason != "" {
+		fmt.Fprintf(tw, "Miss reason\t%s\n", in.MissReason)
+	}
+	tw.Flush()
+}
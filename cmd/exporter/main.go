@@ -1,82 +1,76 @@
 package main
 
 import (
-	"context"
-	"flag"
 	"fmt"
-	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
-	"time"
+	"strings"
 
-	"cosmos-evm-exporter/internal/blockchain"
 	"cosmos-evm-exporter/internal/config"
 	"cosmos-evm-exporter/internal/logger"
-	"cosmos-evm-exporter/internal/metrics"
-
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-func main() {
-	// Parse command line flags
-	configFile := flag.String("config", "config.yaml", "Path to config file")
-	flag.Parse()
+const usage = `Usage: evm-exporter [command] [flags]
 
-	// Load configuration
-	cfg, err := config.LoadConfig(*configFile)
-	if err != nil {
-		fmt.Printf("Failed to load config: %v\n", err)
-		os.Exit(1)
-	}
+Commands:
+  run     Run the exporter (default)
+  query   Report on proposals recorded in the store
+  export  Export proposal records as CSV or Parquet
+  report  Generate a per-validator SLA report
+  inspect Show the decoded block and EL match of one consensus height
 
-	// Initialize logger
-	log := logger.NewLogger(&logger.Config{
-		EnableStdout: cfg.EnableStdout,
-		LogFile:      cfg.LogFile,
-	})
+Run "evm-exporter <command> -h" for the flags of a command.
+`
 
-	// Initialize metrics and register with default prometheus handler
-	metrics := metrics.NewBlockMetrics()
-	http.Handle("/metrics", promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{}))
+func main() {
+	args := os.Args[1:]
+
+	// Without a command, run the exporter so existing invocations keep working
+	command := "run"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
 
-	// Start metrics server
-	go func() {
-		if err := http.ListenAndServe(cfg.MetricsPort, nil); err != nil {
-			log.WriteJSONLog("error", "Failed to start metrics server", nil, err)
-			os.Exit(1)
-		}
-	}()
+	switch command {
+	case "run":
+		runExporter(args)
+	case "query":
+		runQuery(args)
+	case "export":
+		runExport(args)
+	case "report":
+		runReport(args)
+	case "inspect":
+		runInspect(args)
+	case "help":
+		fmt.Print(usage)
+	default:
+		fmt.Printf("Unknown command %q\n\n%s", command, usage)
+		os.Exit(2)
+	}
+}
 
-	// Initialize block processor
-	processor, err := blockchain.NewBlockProcessor(cfg, metrics, log)
+func mustLoadConfig(path string) *config.Config {
+	cfg, err := config.LoadConfig(path)
 	if err != nil {
-		log.WriteJSONLog("error", "Failed to create block processor", nil, err)
+		fmt.Printf("Failed to load config: %v\n", err)
 		os.Exit(1)
 	}
+	return cfg
+}
 
-	// Setup graceful shutdown
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	// Handle interrupt signals
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-
-	go func() {
-		<-sigChan
-		log.WriteJSONLog("info", "Shutting down...", nil, nil)
-		cancel()
-	}()
-
-	// Start metrics updater
-	processor.StartMetricsUpdater(ctx, 5*time.Second)
-
-	// Log startup
-	log.WriteJSONLog("info", "Starting exporter", map[string]interface{}{
-		"metrics_port": cfg.MetricsPort,
-	}, nil)
-
-	// Start processing blocks
-	processor.Start(ctx)
+func newLogger(cfg *config.Config) *logger.Logger {
+	return logger.NewLogger(&logger.Config{
+		EnableFileLog:   cfg.EnableFileLog,
+		EnableStdout:    cfg.EnableStdout,
+		LogFile:         cfg.LogFile,
+		Level:           cfg.LogLevel,
+		ComponentLevels: cfg.LogLevels,
+		MaxSizeMB:       cfg.LogMaxSizeMB,
+		MaxAgeHours:     cfg.LogMaxAgeHours,
+		MaxBackups:      cfg.LogMaxBackups,
+		Compress:        cfg.LogCompress,
+		FileMode:        cfg.LogFileMode,
+		Format:          cfg.LogFormat,
+		Fields:          cfg.LogFields,
+	})
 }
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"cosmos-evm-exporter/internal/proposals"
+	"cosmos-evm-exporter/internal/store"
+)
+
+// runQuery prints per-day or per-validator proposal reports, either from the
+// store file or from the history API of a running exporter.
+func runQuery(args []string) {
+	flags := flag.NewFlagSet("query", flag.ExitOnError)
+	configFile := flags.String("config", "", "Path to config file, used to find store_path")
+	dbPath := flags.String("db", "", "Path to the store file (overrides store_path)")
+	apiURL := flags.String("url", "", "Base URL of a running exporter, e.g. http://localhost:2113")
+	groupBy := flags.String("by", proposals.GroupByDay, "Group by day or validator")
+	validator := flags.String("validator", "", "Only include this validator")
+	from := flags.String("from", "", "Start consensus height or RFC3339 time")
+	to := flags.String("to", "", "End consensus height or RFC3339 time")
+	format := flags.String("format", "table", "Output format: table or json")
+	attempts := flags.Int64("attempts", 0, "List the EL match attempts of this consensus height instead")
+	showErrors := flags.Bool("errors", false, "List processing errors instead, between --from and --to times")
+	flags.Parse(args)
+
+	if *format != "table" && *format != "json" {
+		fmt.Printf("Unknown format %q\n", *format)
+		os.Exit(2)
+	}
+	if *attempts > 0 {
+		queryAttempts(*configFile, *dbPath, *apiURL, *attempts, *format)
+		return
+	}
+	if *showErrors {
+		queryErrors(*configFile, *dbPath, *apiURL, *from, *to, *format)
+		return
+	}
+
+	query := url.Values{}
+	for key, value := range map[string]string{"validator": *validator, "from": *from, "to": *to} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	records, err := loadRecords(*configFile, *dbPath, *apiURL, query)
+	if err != nil {
+		fmt.Printf("Failed to load proposals: %v\n", err)
+		os.Exit(1)
+	}
+
+	summaries, err := proposals.Summarize(records, *groupBy)
+	if err != nil {
+		fmt.Printf("Failed to summarize proposals: %v\n", err)
+		os.Exit(1)
+	}
+
+	switch *format {
+	case "json":
+		writeIndentedJSON(os.Stdout, summaries)
+	case "table":
+		writeSummaryTable(os.Stdout, *groupBy, summaries)
+	}
+}
+
+// queryAttempts prints every EL block inspected while matching the proposal
+// at clHeight.
+func queryAttempts(configFile, dbPath, apiURL string, clHeight int64, format string) {
+	var attempts []proposals.Attempt
+	if apiURL != "" {
+		var resp struct {
+			Attempts []proposals.Attempt `json:"attempts"`
+		}
+		err := fetchJSON(apiURL+"/api/v1/attempts", url.Values{"height": {strconv.FormatInt(clHeight, 10)}}, &resp)
+		if err != nil {
+			fmt.Printf("Failed to load attempts: %v\n", err)
+			os.Exit(1)
+		}
+		attempts = resp.Attempts
+	} else {
+		db, err := openStore(configFile, dbPath)
+		if err != nil {
+			fmt.Printf("Failed to load attempts: %v\n", err)
+			os.Exit(1)
+		}
+		defer db.Close()
+		if attempts, err = db.Attempts(clHeight); err != nil {
+			fmt.Printf("Failed to load attempts: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if format == "json" {
+		writeIndentedJSON(os.Stdout, attempts)
+		return
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "el_height\tcoinbase\tmatched\terror\ttime\n")
+	for _, a := range attempts {
+		fmt.Fprintf(tw, "%d\t%s\t%t\t%s\t%s\n", a.ELHeight, a.Coinbase, a.Matched, a.Error, a.Time.Format(time.RFC3339))
+	}
+	tw.Flush()
+}
+
+// queryErrors prints the processing errors recorded between the RFC3339
+// times from and to.
+func queryErrors(configFile, dbPath, apiURL, from, to, format string) {
+	bounds := url.Values{}
+	for key, value := range map[string]string{"from": from, "to": to} {
+		if value != "" {
+			bounds.Set(key, value)
+		}
+	}
+
+	var events []proposals.ErrorEvent
+	if apiURL != "" {
+		var resp struct {
+			Errors []proposals.ErrorEvent `json:"errors"`
+		}
+		if err := fetchJSON(apiURL+"/api/v1/errors", bounds, &resp); err != nil {
+			fmt.Printf("Failed to load errors: %v\n", err)
+			os.Exit(1)
+		}
+		events = resp.Errors
+	} else {
+		fromTime, toTime, err := proposals.ParseTimeRange(bounds)
+		if err != nil {
+			fmt.Printf("Failed to load errors: %v\n", err)
+			os.Exit(2)
+		}
+		db, err := openStore(configFile, dbPath)
+		if err != nil {
+			fmt.Printf("Failed to load errors: %v\n", err)
+			os.Exit(1)
+		}
+		defer db.Close()
+		if events, err = db.Errors(fromTime, toTime); err != nil {
+			fmt.Printf("Failed to load errors: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if format == "json" {
+		writeIndentedJSON(os.Stdout, events)
+		return
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "time\theight\tcomponent\tmessage\terror\n")
+	for _, e := range events {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\n", e.Time.Format(time.RFC3339), e.Height, e.Component, e.Message, e.Error)
+	}
+	tw.Flush()
+}
+
+func writeIndentedJSON(w io.Writer, v interface{}) {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+// loadRecords returns every record matching query from the API when apiURL is
+// set, and from the store file otherwise.
+func loadRecords(configFile, dbPath, apiURL string, query url.Values) ([]proposals.Record, error) {
+	if apiURL != "" {
+		return fetchPages[proposals.Record](apiURL+"/api/v1/proposals", "proposals", query)
+	}
+
+	db, err := openStore(configFile, dbPath)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	filter, err := proposals.ParseFilter(query)
+	if err != nil {
+		return nil, err
+	}
+	filter.Limit = 0
+
+	records, _, err := db.Query(filter)
+	return records, err
+}
+
+// openStore opens the store file read-only, taking its path from the config
+// file unless dbPath is set.
+func openStore(configFile, dbPath string) (*store.Store, error) {
+	if dbPath == "" && configFile != "" {
+		dbPath = mustLoadConfig(configFile).StorePath
+	}
+	if dbPath == "" {
+		return nil, fmt.Errorf("no store configured: pass --db, --config with store_path, or --url")
+	}
+
+	db, err := store.Open(dbPath, true)
+	if errors.Is(err, store.ErrLocked) {
+		return nil, fmt.Errorf("%w (the exporter is running, use --url instead)", err)
+	}
+	return db, err
+}
+
+// fetchPages pages through a list endpoint of a running exporter and returns
+// the entries found under key.
+func fetchPages[T any](endpoint, key string, query url.Values) ([]T, error) {
+	const pageSize = 1000
+
+	var entries []T
+	for offset := 0; ; offset += pageSize {
+		query.Set("limit", strconv.Itoa(pageSize))
+		query.Set("offset", strconv.Itoa(offset))
+
+		resp, err := http.Get(endpoint + "?" + query.Encode())
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s returned %s: %s", endpoint, resp.Status, body)
+		}
+
+		var page map[string]json.RawMessage
+		var total int
+		var items []T
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to decode %s page: %w", key, err)
+		}
+		if err := json.Unmarshal(page["total"], &total); err != nil {
+			return nil, fmt.Errorf("failed to decode %s page: %w", key, err)
+		}
+		if err := json.Unmarshal(page[key], &items); err != nil {
+			return nil, fmt.Errorf("failed to decode %s page: %w", key, err)
+		}
+
+		entries = append(entries, items...)
+		if len(items) == 0 || len(entries) >= total {
+			return entries, nil
+		}
+	}
+}
+
+// fetchJSON decodes the response of a single GET to an exporter endpoint.
+func fetchJSON(endpoint string, query url.Values, v interface{}) error {
+	resp, err := http.Get(endpoint + "?" + query.Encode())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s: %s", endpoint, resp.Status, body)
+	}
+	return json.Unmarshal(body, v)
+}
+
+func writeSummaryTable(w io.Writer, groupBy string, summaries []proposals.Summary) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "%s\tproposals\tconfirmed\tmissed\terrors\tempty_cl\tempty_el\tsuccess_rate\t\n", groupBy)
+	for _, s := range summaries {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%d\t%d\t%.2f%%\t\n",
+			s.Key, s.Proposals, s.Confirmed, s.Missed, s.Errors, s.EmptyConsensus, s.EmptyExecution, s.SuccessRate*100)
+	}
+	tw.Flush()
+}
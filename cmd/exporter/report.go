@@ -0,0 +1,110 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+
+	"cosmos-evm-exporter/internal/proposals"
+	"cosmos-evm-exporter/internal/report"
+)
+
+// runReport prints a per-validator SLA report for a period. Like export, it
+// reads the store, a running exporter's APIs or a one-off backfill.
+func runReport(args []string) {
+	flags := flag.NewFlagSet("report", flag.ExitOnError)
+	configFile := flags.String("config", "", "Path to config file")
+	dbPath := flags.String("db", "", "Path to the store file (overrides store_path)")
+	apiURL := flags.String("url", "", "Base URL of a running exporter, e.g. http://localhost:2113")
+	backfill := flags.Bool("backfill", false, "Process --from..--to from the RPC endpoints instead of reading recorded data")
+	validator := flags.String("validator", "", "Only include this validator")
+	from := flags.String("from", "", "Start consensus height or RFC3339 time")
+	to := flags.String("to", "", "End consensus height or RFC3339 time")
+	minOutage := flags.Int("min-outage", 3, "Shortest signing outage, in blocks, listed as an incident")
+	format := flags.String("format", report.FormatMarkdown, "Output format: markdown, html or json")
+	output := flags.String("output", "", "Output file (default stdout)")
+	flags.Parse(args)
+
+	query := url.Values{}
+	for key, value := range map[string]string{"validator": *validator, "from": *from, "to": *to} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	var records []proposals.Record
+	var signatures []proposals.Signature
+	var err error
+	if *backfill {
+		var memory *proposals.MemoryStore
+		memory, err = backfillStore(*configFile, *from, *to, *output != "")
+		if err == nil {
+			records, _, err = memory.Query(proposals.Filter{Validator: *validator})
+		}
+		if err == nil {
+			signatures, _, err = memory.Signatures(proposals.Filter{Validator: *validator})
+		}
+	} else {
+		records, signatures, err = loadReportData(*configFile, *dbPath, *apiURL, query)
+	}
+	if err != nil {
+		fmt.Printf("Failed to load report data: %v\n", err)
+		os.Exit(1)
+	}
+
+	sla := report.Build(records, signatures, report.Options{
+		From:      *from,
+		To:        *to,
+		MinOutage: *minOutage,
+	})
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			fmt.Printf("Failed to create output file: %v\n", err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if err := report.Write(w, *format, sla); err != nil {
+		fmt.Printf("Failed to write report: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// loadReportData returns the proposal records and signatures matching query
+// from the APIs when apiURL is set, and from the store file otherwise.
+func loadReportData(configFile, dbPath, apiURL string, query url.Values) ([]proposals.Record, []proposals.Signature, error) {
+	if apiURL != "" {
+		records, err := fetchPages[proposals.Record](apiURL+"/api/v1/proposals", "proposals", query)
+		if err != nil {
+			return nil, nil, err
+		}
+		signatures, err := fetchPages[proposals.Signature](apiURL+"/api/v1/signatures", "signatures", query)
+		return records, signatures, err
+	}
+
+	db, err := openStore(configFile, dbPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer db.Close()
+
+	filter, err := proposals.ParseFilter(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	filter.Limit = 0
+
+	records, _, err := db.Query(filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	signatures, _, err := db.Signatures(filter)
+	return records, signatures, err
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"cosmos-evm-exporter/internal/blockchain"
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/events"
+	"cosmos-evm-exporter/internal/logger"
+	"cosmos-evm-exporter/internal/metrics"
+	"cosmos-evm-exporter/internal/proposals"
+	"cosmos-evm-exporter/internal/store"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// runExporter runs the exporter until it receives SIGINT or SIGTERM.
+func runExporter(args []string) {
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	configFile := flags.String("config", "config.yaml", "Path to config file")
+	flags.Parse(args)
+
+	cfg := mustLoadConfig(*configFile)
+
+	// Initialize logger
+	log := newLogger(cfg)
+	defer log.Close()
+
+	// Initialize metrics and register with default prometheus handler
+	blockMetrics := metrics.NewBlockMetrics()
+	http.Handle("/metrics", promhttp.HandlerFor(blockMetrics.Registry, promhttp.HandlerOpts{}))
+
+	// Initialize block processor
+	processor, err := blockchain.NewBlockProcessor(cfg, blockMetrics, log)
+	if err != nil {
+		log.WriteJSONLog(logger.LevelError, "Failed to create block processor", nil, err)
+		os.Exit(1)
+	}
+
+	// Keep proposal outcomes for the history API, on disk when a store is configured
+	var proposalStore proposals.Store = proposals.NewMemoryStore(cfg.ProposalHistorySize)
+	if cfg.StorePath != "" {
+		db, err := store.Open(cfg.StorePath, false)
+		if err != nil {
+			log.WriteJSONLog(logger.LevelError, "Failed to open store", nil, err)
+			os.Exit(1)
+		}
+		defer db.Close()
+		proposalStore = db
+	}
+	processor.SetProposalStore(proposalStore)
+	http.Handle("/api/v1/proposals", proposals.Handler(proposalStore))
+	if signing, ok := proposalStore.(proposals.SigningStore); ok {
+		http.Handle("/api/v1/signatures", proposals.SignaturesHandler(signing))
+	}
+	if attempts, ok := proposalStore.(proposals.AttemptReader); ok {
+		http.Handle("/api/v1/attempts", proposals.AttemptsHandler(attempts))
+	}
+	if errors, ok := proposalStore.(proposals.ErrorReader); ok {
+		http.Handle("/api/v1/errors", proposals.ErrorsHandler(errors))
+	}
+
+	// Start metrics server
+	go func() {
+		if err := http.ListenAndServe(cfg.MetricsPort, nil); err != nil {
+			log.WriteJSONLog(logger.LevelError, "Failed to start metrics server", nil, err)
+			os.Exit(1)
+		}
+	}()
+
+	// Serve admin endpoints on their own listener, off unless configured
+	if cfg.AdminAddress != "" {
+		adminMux := http.NewServeMux()
+		adminMux.Handle("/admin/log-level", log.LevelHandler())
+		go func() {
+			if err := http.ListenAndServe(adminListenAddress(cfg.AdminAddress), adminMux); err != nil {
+				log.WriteJSONLog(logger.LevelError, "Failed to start admin server", nil, err)
+				os.Exit(1)
+			}
+		}()
+	}
+
+	// Setup graceful shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Handle interrupt signals
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		<-sigChan
+		log.WriteJSONLog(logger.LevelInfo, "Shutting down...", nil, nil)
+		cancel()
+	}()
+
+	// Reopen the log file on SIGUSR1 so logrotate can move it away
+	reopenChan := make(chan os.Signal, 1)
+	signal.Notify(reopenChan, syscall.SIGUSR1)
+
+	go func() {
+		for range reopenChan {
+			if err := log.Reopen(); err != nil {
+				log.WriteJSONLog(logger.LevelError, "Failed to reopen log file", nil, err)
+				continue
+			}
+			log.WriteJSONLog(logger.LevelInfo, "Reopened log file", nil, nil)
+		}
+	}()
+
+	// Start metrics updater
+	processor.StartMetricsUpdater(ctx, 5*time.Second)
+
+	// Export consensus node health
+	nodeMetrics := metrics.NewNodeMetrics(blockMetrics.Registry)
+	blockchain.NewNodeMonitor(cfg, nodeMetrics, log).Start(ctx, 15*time.Second)
+
+	// Export execution client health
+	executionMonitor, err := blockchain.NewExecutionMonitor(cfg, metrics.NewExecutionNodeMetrics(blockMetrics.Registry), log)
+	if err != nil {
+		log.WriteJSONLog(logger.LevelError, "Failed to create execution monitor", nil, err)
+		os.Exit(1)
+	}
+	executionMonitor.Start(ctx, 15*time.Second)
+
+	// Probe the Engine API when it is configured
+	if cfg.EngineEndpoint != "" {
+		engineMonitor, err := blockchain.NewEngineMonitor(cfg, metrics.NewEngineMetrics(blockMetrics.Registry), log)
+		if err != nil {
+			log.WriteJSONLog(logger.LevelError, "Failed to create Engine API monitor", nil, err)
+			os.Exit(1)
+		}
+		engineMonitor.Start(ctx, 15*time.Second)
+	}
+
+	// Follow upgrade plans, staking, slashing and governance state through
+	// the REST API
+	if cfg.LCDEndpoint != "" {
+		cosmosMetrics := metrics.NewCosmosMetrics(blockMetrics.Registry)
+
+		upgradeMonitor := blockchain.NewUpgradeMonitor(cfg, cosmosMetrics, processor.BlockTimes(), log)
+		processor.SetUpgradeHalt(upgradeMonitor)
+		upgradeMonitor.Start(ctx, 30*time.Second)
+
+		if len(cfg.Validators) > 0 {
+			blockchain.NewStakingMonitor(cfg, cosmosMetrics, log).Start(ctx, time.Minute)
+
+			governanceMonitor, err := blockchain.NewGovernanceMonitor(cfg, cosmosMetrics, log)
+			if err != nil {
+				log.WriteJSONLog(logger.LevelError, "Failed to create governance monitor", nil, err)
+				os.Exit(1)
+			}
+			governanceMonitor.Start(ctx, 5*time.Minute)
+		}
+	}
+
+	// Watch contract events when any are configured
+	if len(cfg.EventWatches) > 0 {
+		eventWatcher, err := events.NewWatcher(cfg, metrics.NewEventMetrics(blockMetrics.Registry), log)
+		if err != nil {
+			log.WriteJSONLog(logger.LevelError, "Failed to create event watcher", nil, err)
+			os.Exit(1)
+		}
+		eventWatcher.Start(ctx, 15*time.Second)
+	}
+
+	// Follow consensus rounds to catch failed proposals
+	if cfg.TrackConsensusRounds {
+		processor.StartConsensusTracker(ctx, time.Duration(cfg.ConsensusPollIntervalMs)*time.Millisecond)
+	}
+
+	// Apply store retention policies
+	if db, ok := proposalStore.(*store.Store); ok {
+		db.StartPruning(ctx, storeRetention(cfg), time.Hour, log.Component("store"))
+	}
+
+	// Log startup
+	log.WriteJSONLog(logger.LevelInfo, "Starting exporter", map[string]interface{}{
+		"metrics_port": cfg.MetricsPort,
+	}, nil)
+
+	// Start processing blocks
+	processor.Start(ctx)
+}
+
+// storeRetention converts the store retention settings from days into a policy.
+func storeRetention(cfg *config.Config) store.Retention {
+	day := 24 * time.Hour
+	return store.Retention{
+		Proposals:  time.Duration(cfg.StoreRetentionDays) * day,
+		Attempts:   time.Duration(cfg.StoreAttemptRetentionDays) * day,
+		Errors:     time.Duration(cfg.StoreErrorRetentionDays) * day,
+		Signatures: time.Duration(cfg.StoreSigningRetentionDays) * day,
+	}
+}
+
+// adminListenAddress binds the admin server to localhost when the address
+// only names a port.
+func adminListenAddress(address string) string {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil || host != "" {
+		return address
+	}
+	return net.JoinHostPort("127.0.0.1", port)
+}
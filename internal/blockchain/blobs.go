@@ -0,0 +1,185 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"cosmos-evm-exporter/internal/logger"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// OtherProposers is the validator label of blob metrics for EL blocks that
+// were not matched to our proposals.
+const OtherProposers = "other"
+
+// blobCatchUpLimit is the most EL blocks one blob update observes, so a long
+// pause doesn't turn into a burst of requests.
+const blobCatchUpLimit = 100
+
+// HeaderClient is implemented by EL clients that fetch headers without the
+// transactions of the block.
+type HeaderClient interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// BlobFeeClient is implemented by EL clients that report the blob base fee.
+// The fee depends on the blob schedule of the fork, so it is asked from the
+// node rather than computed from the excess blob gas.
+type BlobFeeClient interface {
+	BlobBaseFee(ctx context.Context) (*big.Int, error)
+}
+
+// headerCache keeps the headers of the EL blocks the scans fetched, so the
+// blob updater doesn't fetch them again, and which of them are ours.
+type headerCache struct {
+	mu      sync.Mutex
+	headers map[int64]*types.Header
+	ours    map[int64]bool
+}
+
+func newHeaderCache() *headerCache {
+	return &headerCache{headers: map[int64]*types.Header{}, ours: map[int64]bool{}}
+}
+
+func (c *headerCache) add(header *types.Header) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	height := header.Number.Int64()
+	c.headers[height] = header
+	for h := range c.headers {
+		if h <= height-blobCatchUpLimit {
+			delete(c.headers, h)
+		}
+	}
+	for h := range c.ours {
+		if h <= height-blobCatchUpLimit {
+			delete(c.ours, h)
+		}
+	}
+}
+
+// markOurs records that a scan found the EL block at height to be ours,
+// including blocks built for us by a builder.
+func (c *headerCache) markOurs(height int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ours[height] = true
+}
+
+func (c *headerCache) isOurs(height int64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ours[height]
+}
+
+func (c *headerCache) get(height int64) (*types.Header, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	header, ok := c.headers[height]
+	return header, ok
+}
+
+// BlobCount returns the number of blobs of the block with header, from the
+// blob gas it used.
+func BlobCount(header *types.Header) int {
+	if header.BlobGasUsed == nil {
+		return 0
+	}
+	return int(*header.BlobGasUsed / params.BlobTxBlobGasPerBlob)
+}
+
+// observeBlobs records the blob usage of the block with header under
+// validator. Blocks from before blobs were enabled have no blob gas fields
+// and are skipped.
+func (p *BlockProcessor) observeBlobs(validator string, header *types.Header) {
+	blobGasUsed := header.BlobGasUsed
+	if blobGasUsed == nil {
+		return
+	}
+	p.metrics.BlockBlobs.WithLabelValues(validator).Observe(float64(BlobCount(header)))
+	p.metrics.BlockBlobGasUsed.WithLabelValues(validator).Observe(float64(*blobGasUsed))
+}
+
+// header returns the header of the EL block at height, from the blocks
+// already fetched when possible.
+func (p *BlockProcessor) header(height int64) (*types.Header, error) {
+	if header, ok := p.headers.get(height); ok {
+		return header, nil
+	}
+	if client, ok := p.client.(HeaderClient); ok {
+		return client.HeaderByNumber(context.Background(), big.NewInt(height))
+	}
+	block, err := p.client.BlockByNumber(context.Background(), big.NewInt(height))
+	if err != nil {
+		return nil, err
+	}
+	return block.Header(), nil
+}
+
+// updateBlobMetrics observes the EL blocks produced since the last update
+// that are not ours, ours being observed when they are matched to a
+// proposal, and sets the network blob gauges from the latest one.
+func (p *BlockProcessor) updateBlobMetrics() error {
+	head, err := p.GetCurrentELHeight()
+	if err != nil {
+		return fmt.Errorf("failed to get EL height: %w", err)
+	}
+
+	start := p.lastBlobHeight + 1
+	if p.lastBlobHeight == 0 {
+		start = head
+	} else if head-start >= blobCatchUpLimit {
+		start = head - blobCatchUpLimit + 1
+	}
+
+	blobs := false
+	for height := start; height <= head; height++ {
+		header, err := p.header(height)
+		if err != nil {
+			return fmt.Errorf("failed to fetch block %d: %w", height, err)
+		}
+		if header.Coinbase.Hex() != p.config.EVMAddress && !p.headers.isOurs(height) {
+			p.observeBlobs(OtherProposers, header)
+		}
+		if header.ExcessBlobGas != nil {
+			p.metrics.NetworkExcessBlobGas.Set(float64(*header.ExcessBlobGas))
+			blobs = true
+		}
+		p.lastBlobHeight = height
+	}
+
+	if blobs {
+		p.updateBlobBaseFee()
+	}
+	return nil
+}
+
+// updateBlobBaseFee sets the blob base fee reported by the EL. Clients that
+// don't know eth_blobBaseFee are not asked again.
+func (p *BlockProcessor) updateBlobBaseFee() {
+	client, ok := p.client.(BlobFeeClient)
+	if !ok || p.noBlobBaseFee {
+		return
+	}
+
+	fee, err := client.BlobBaseFee(context.Background())
+	if err != nil {
+		var rpcErr gethrpc.Error
+		if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == methodNotFound {
+			p.noBlobBaseFee = true
+			p.rpcLogger.WriteJSONLog(logger.LevelInfo, "Execution client does not expose eth_blobBaseFee, no longer asking for it", nil, nil)
+			return
+		}
+		p.rpcLogger.WriteJSONLog(logger.LevelWarn, "Failed to get blob base fee", nil, err)
+		return
+	}
+	baseFee, _ := new(big.Float).SetInt(fee).Float64()
+	p.metrics.NetworkBlobBaseFee.Set(baseFee)
+}
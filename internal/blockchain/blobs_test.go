@@ -0,0 +1,142 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/metrics"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestUpdateBlobMetrics(t *testing.T) {
+	ours := common.HexToAddress("0xabc")
+	blobBlock := func(height int64, coinbase common.Address, blobs int) *types.Block {
+		blobGasUsed := uint64(blobs) * 131072
+		excessBlobGas := uint64(height) * 1000000
+		hashes := make([]common.Hash, blobs)
+		return types.NewBlockWithHeader(&types.Header{
+			Number:        big.NewInt(height),
+			Coinbase:      coinbase,
+			BlobGasUsed:   &blobGasUsed,
+			ExcessBlobGas: &excessBlobGas,
+		}).WithBody(types.Body{Transactions: []*types.Transaction{types.NewTx(&types.BlobTx{BlobHashes: hashes})}})
+	}
+
+	head := 100
+	elServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":"0x%x"}`, head)
+	}))
+	defer elServer.Close()
+
+	blockMetrics := metrics.NewBlockMetrics()
+	processor, err := NewBlockProcessor(&config.Config{
+		TargetValidator: "validator1",
+		EVMAddress:      ours.Hex(),
+		ETHEndpoint:     elServer.URL,
+	}, blockMetrics, newTestLogger())
+	if err != nil {
+		t.Fatalf("Failed to create processor: %v", err)
+	}
+	blocks := map[int64]*types.Block{
+		100: blobBlock(100, common.HexToAddress("0xdef"), 1),
+		101: blobBlock(101, ours, 6),
+		102: blobBlock(102, common.HexToAddress("0xbeef"), 3), // Built for us by a builder
+		103: types.NewBlockWithHeader(&types.Header{Number: big.NewInt(103)}), // Before blobs
+	}
+	client := &MockBlobFeeClient{MockEthClient: MockEthClient{blocks: blocks}, fee: big.NewInt(1e9)}
+	processor.client = client
+
+	// The first update only looks at the head
+	if err := processor.updateBlobMetrics(); err != nil {
+		t.Fatalf("updateBlobMetrics() error = %v", err)
+	}
+
+	// Blocks already fetched by a scan are not fetched again
+	for height := int64(101); height <= 103; height++ {
+		processor.headers.add(blocks[height].Header())
+	}
+	processor.headers.markOurs(102)
+	client.failAt = map[int64]bool{101: true, 102: true, 103: true}
+	head = 103
+	if err := processor.updateBlobMetrics(); err != nil {
+		t.Fatalf("updateBlobMetrics() error = %v", err)
+	}
+
+	// Our blocks are observed when they are matched to a proposal
+	processor.observeBlobs("validator1", blocks[101].Header())
+	processor.observeBlobs("validator1", blocks[102].Header())
+
+	if got := testutil.CollectAndCount(blockMetrics.BlockBlobs, "validator_el_block_blobs"); got != 2 {
+		t.Errorf("Expected blob histograms for 2 validators, got %d", got)
+	}
+	if count, sum := blobHistogram(t, blockMetrics, OtherProposers); count != 1 || sum != 1 {
+		t.Errorf("Expected block 100 with 1 blob for other proposers, got %d blocks with %v blobs", count, sum)
+	}
+	if count, sum := blobHistogram(t, blockMetrics, "validator1"); count != 2 || sum != 9 {
+		t.Errorf("Expected our blocks with 9 blobs, got %d blocks with %v blobs", count, sum)
+	}
+	if got := testutil.ToFloat64(blockMetrics.NetworkExcessBlobGas); got != 102000000 {
+		t.Errorf("Expected excess blob gas of block 102, got %v", got)
+	}
+	if got := testutil.ToFloat64(blockMetrics.NetworkBlobBaseFee); got != 1e9 {
+		t.Errorf("Expected the blob base fee reported by the EL, got %v", got)
+	}
+
+	// A client without eth_blobBaseFee is asked once
+	client.feeErr = rpcError{code: methodNotFound}
+	for height := int64(104); height <= 105; height++ {
+		head = int(height)
+		blocks[height] = blobBlock(height, common.HexToAddress("0xdef"), 0)
+		if err := processor.updateBlobMetrics(); err != nil {
+			t.Fatalf("updateBlobMetrics() error = %v", err)
+		}
+	}
+	if client.feeCalls != 3 {
+		t.Errorf("Expected eth_blobBaseFee to be asked once per update until method not found, got %d calls", client.feeCalls)
+	}
+}
+
+// MockBlobFeeClient adds BlobBaseFee to MockEthClient
+type MockBlobFeeClient struct {
+	MockEthClient
+	fee      *big.Int
+	feeErr   error
+	feeCalls int
+}
+
+func (m *MockBlobFeeClient) BlobBaseFee(ctx context.Context) (*big.Int, error) {
+	m.feeCalls++
+	if m.feeErr != nil {
+		return nil, m.feeErr
+	}
+	return m.fee, nil
+}
+
+// blobHistogram returns the sample count and sum of the blob histogram of
+// validator.
+func blobHistogram(t *testing.T, blockMetrics *metrics.BlockMetrics, validator string) (uint64, float64) {
+	t.Helper()
+	families, err := blockMetrics.Registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "validator_el_block_blobs" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			if metric.GetLabel()[0].GetValue() == validator {
+				return metric.GetHistogram().GetSampleCount(), metric.GetHistogram().GetSampleSum()
+			}
+		}
+	}
+	return 0, 0
+}
@@ -0,0 +1,67 @@
+package blockchain
+
+import (
+	"sync"
+	"time"
+)
+
+const blockTimeSamples = 100
+
+// BlockTimes keeps the times of the most recent consecutive blocks seen by
+// the processor, to estimate when a future height will be reached.
+type BlockTimes struct {
+	mu      sync.Mutex
+	heights []int64
+	times   []time.Time
+}
+
+func NewBlockTimes() *BlockTimes {
+	return &BlockTimes{}
+}
+
+// Observe records the time of height. Heights going backwards, as in a
+// backfill, restart the samples.
+func (b *BlockTimes) Observe(height int64, at time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if n := len(b.heights); n > 0 && height <= b.heights[n-1] {
+		if height == b.heights[n-1] {
+			return
+		}
+		b.heights, b.times = nil, nil
+	}
+
+	b.heights = append(b.heights, height)
+	b.times = append(b.times, at)
+	if len(b.heights) > blockTimeSamples {
+		b.heights = b.heights[1:]
+		b.times = b.times[1:]
+	}
+}
+
+// Latest returns the highest observed height and its time.
+func (b *BlockTimes) Latest() (int64, time.Time, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	n := len(b.heights)
+	if n == 0 {
+		return 0, time.Time{}, false
+	}
+	return b.heights[n-1], b.times[n-1], true
+}
+
+// Average returns the mean time between the observed blocks, or false
+// before two blocks were seen.
+func (b *BlockTimes) Average() (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	n := len(b.heights)
+	if n < 2 {
+		return 0, false
+	}
+	blocks := b.heights[n-1] - b.heights[0]
+	return b.times[n-1].Sub(b.times[0]) / time.Duration(blocks), true
+}
@@ -0,0 +1,158 @@
+package blockchain
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/logger"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Builder labels of blocks that match no configured builder.
+const (
+	BuilderLocal   = "local"   // Built by our own execution client
+	BuilderUnknown = "unknown" // Paid for by a builder missing from the registry
+)
+
+// builder is a compiled entry of the builder registry.
+type builder struct {
+	name      string
+	extraData *regexp.Regexp
+	addresses map[common.Address]bool
+}
+
+// compileBuilders checks the builder registry and compiles its patterns.
+func compileBuilders(cfgs []config.BuilderConfig) ([]builder, error) {
+	var builders []builder
+	for i, c := range cfgs {
+		if c.Name == "" {
+			return nil, fmt.Errorf("builder %d has no name", i)
+		}
+		if c.Name == BuilderLocal || c.Name == BuilderUnknown {
+			return nil, fmt.Errorf("builder name %q is reserved", c.Name)
+		}
+
+		b := builder{name: c.Name, addresses: map[common.Address]bool{}}
+		if c.ExtraData != "" {
+			pattern, err := regexp.Compile(c.ExtraData)
+			if err != nil {
+				return nil, fmt.Errorf("builder %s: invalid extra_data pattern: %w", c.Name, err)
+			}
+			b.extraData = pattern
+		}
+		for _, address := range c.Addresses {
+			if !common.IsHexAddress(address) {
+				return nil, fmt.Errorf("builder %s: invalid address %q", c.Name, address)
+			}
+			b.addresses[common.HexToAddress(address)] = true
+		}
+		if b.extraData == nil && len(b.addresses) == 0 {
+			return nil, fmt.Errorf("builder %s needs extra_data or addresses", c.Name)
+		}
+		builders = append(builders, b)
+	}
+	return builders, nil
+}
+
+// Attribution tells who built an execution block.
+type Attribution struct {
+	Builder string
+	Payer   common.Address // Sender of the payment, zero without one
+	Payment *big.Int       // Value paid to the fee recipient, nil without one
+}
+
+// builderPayment returns the sender and value of the last transaction of
+// block when it transfers value to feeRecipient from another account, the
+// way builders pay the proposer.
+func builderPayment(block *types.Block, feeRecipient common.Address) (common.Address, *big.Int, bool) {
+	txs := block.Transactions()
+	if len(txs) == 0 {
+		return common.Address{}, nil, false
+	}
+	last := txs[len(txs)-1]
+	if last.To() == nil || *last.To() != feeRecipient || last.Value().Sign() <= 0 {
+		return common.Address{}, nil, false
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(last.ChainId()), last)
+	if err != nil || sender == feeRecipient {
+		return common.Address{}, nil, false
+	}
+	return sender, last.Value(), true
+}
+
+// isOurBlock tells whether block was produced for our proposal: built with
+// our coinbase, or by a builder of the registry that pays our fee recipient
+// in its last transaction. Payments from unlisted accounts don't count, since
+// anyone can send value to the fee recipient in another proposer's block.
+func (p *BlockProcessor) isOurBlock(block *types.Block) bool {
+	if block.Coinbase().Hex() == p.config.EVMAddress {
+		return true
+	}
+	feeRecipient := common.HexToAddress(p.config.EVMAddress)
+	if _, _, paid := builderPayment(block, feeRecipient); !paid {
+		return false
+	}
+	builder := attributeBlock(block, feeRecipient, p.builders).Builder
+	return builder != BuilderUnknown && builder != BuilderLocal
+}
+
+// attributeBlock works out who built block for feeRecipient. A builder of
+// the registry matches on the extraData, the payer of the last transaction
+// or a coinbase other than the fee recipient. A block with a payment from
+// an unlisted builder is unknown, and any other block local.
+func attributeBlock(block *types.Block, feeRecipient common.Address, builders []builder) Attribution {
+	var attribution Attribution
+	payer, payment, paid := builderPayment(block, feeRecipient)
+	if paid {
+		attribution.Payer, attribution.Payment = payer, payment
+	}
+
+	coinbase := block.Coinbase()
+	for _, b := range builders {
+		switch {
+		case b.extraData != nil && b.extraData.Match(block.Extra()):
+		case paid && b.addresses[payer]:
+		case coinbase != feeRecipient && b.addresses[coinbase]:
+		default:
+			continue
+		}
+		attribution.Builder = b.name
+		return attribution
+	}
+
+	if paid || coinbase != feeRecipient {
+		attribution.Builder = BuilderUnknown
+	} else {
+		attribution.Builder = BuilderLocal
+	}
+	return attribution
+}
+
+// trackBuilder counts the builder of our execution block and the payment it
+// made to the fee recipient.
+func (p *BlockProcessor) trackBuilder(block *types.Block) {
+	attribution := attributeBlock(block, common.HexToAddress(p.config.EVMAddress), p.builders)
+
+	validator := p.config.TargetValidator
+	p.metrics.BuilderBlocks.WithLabelValues(validator, attribution.Builder).Inc()
+	if attribution.Payment != nil {
+		payment, _ := new(big.Float).SetInt(attribution.Payment).Float64()
+		p.metrics.BuilderPayments.WithLabelValues(validator, attribution.Builder).Observe(payment)
+	}
+
+	if attribution.Builder != BuilderLocal {
+		fields := map[string]interface{}{
+			"el_height": block.NumberU64(),
+			"builder":   attribution.Builder,
+		}
+		if attribution.Payment != nil {
+			fields["payer"] = attribution.Payer.Hex()
+			fields["payment_wei"] = attribution.Payment.String()
+		}
+		p.logger.WriteJSONLog(logger.LevelInfo, "Execution block built externally", fields, nil)
+	}
+}
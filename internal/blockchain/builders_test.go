@@ -0,0 +1,228 @@
+package blockchain
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/metrics"
+	"cosmos-evm-exporter/internal/proposals"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCompileBuilders(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfgs    []config.BuilderConfig
+		wantErr bool
+	}{
+		{name: "valid", cfgs: []config.BuilderConfig{{Name: "titan", ExtraData: "(?i)titan", Addresses: []string{"0x0000000000000000000000000000000000000abc"}}}},
+		{name: "no name", cfgs: []config.BuilderConfig{{ExtraData: "titan"}}, wantErr: true},
+		{name: "reserved name", cfgs: []config.BuilderConfig{{Name: BuilderLocal, ExtraData: "local"}}, wantErr: true},
+		{name: "invalid pattern", cfgs: []config.BuilderConfig{{Name: "titan", ExtraData: "("}}, wantErr: true},
+		{name: "invalid address", cfgs: []config.BuilderConfig{{Name: "titan", Addresses: []string{"0x12"}}}, wantErr: true},
+		{name: "nothing to match", cfgs: []config.BuilderConfig{{Name: "titan"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := compileBuilders(tt.cfgs); (err != nil) != tt.wantErr {
+				t.Errorf("compileBuilders() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAttributeBlock(t *testing.T) {
+	feeRecipient := common.HexToAddress("0xabc")
+	builderKey, _ := crypto.GenerateKey()
+	builderAddress := crypto.PubkeyToAddress(builderKey.PublicKey)
+	otherKey, _ := crypto.GenerateKey()
+
+	builders, err := compileBuilders([]config.BuilderConfig{
+		{Name: "titan", ExtraData: "(?i)titan"},
+		{Name: "beaver", Addresses: []string{builderAddress.Hex()}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to compile builders: %v", err)
+	}
+
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	transfer := func(from *ecdsa.PrivateKey, to common.Address, value int64) *types.Transaction {
+		tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), To: &to, Value: big.NewInt(value)}), signer, from)
+		if err != nil {
+			t.Fatalf("Failed to sign transaction: %v", err)
+		}
+		return tx
+	}
+	block := func(extra string, txs ...*types.Transaction) *types.Block {
+		return types.NewBlockWithHeader(&types.Header{Number: big.NewInt(100), Coinbase: feeRecipient, Extra: []byte(extra)}).
+			WithBody(types.Body{Transactions: txs})
+	}
+
+	tests := []struct {
+		name        string
+		block       *types.Block
+		wantBuilder string
+		wantPayment int64
+	}{
+		{name: "local", block: block("geth/v1.14.11"), wantBuilder: BuilderLocal},
+		{name: "extra data", block: block("Titan (titanbuilder.xyz)"), wantBuilder: "titan"},
+		{name: "payment from listed builder", block: block("", transfer(builderKey, feeRecipient, 5e15)), wantBuilder: "beaver", wantPayment: 5e15},
+		{name: "payment from unlisted builder", block: block("", transfer(otherKey, feeRecipient, 7e15)), wantBuilder: BuilderUnknown, wantPayment: 7e15},
+		{name: "last transaction pays someone else", block: block("", transfer(builderKey, common.HexToAddress("0xdef"), 5e15)), wantBuilder: BuilderLocal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := attributeBlock(tt.block, feeRecipient, builders)
+			if got.Builder != tt.wantBuilder {
+				t.Errorf("attributeBlock() builder = %s, want %s", got.Builder, tt.wantBuilder)
+			}
+			switch {
+			case tt.wantPayment == 0 && got.Payment != nil:
+				t.Errorf("Expected no payment, got %s", got.Payment)
+			case tt.wantPayment != 0 && (got.Payment == nil || got.Payment.Int64() != tt.wantPayment):
+				t.Errorf("Expected payment %d, got %v", tt.wantPayment, got.Payment)
+			}
+		})
+	}
+
+	t.Run("metrics", func(t *testing.T) {
+		blockMetrics := metrics.NewBlockMetrics()
+		processor, err := NewBlockProcessor(&config.Config{
+			TargetValidator: "validator1",
+			EVMAddress:      feeRecipient.Hex(),
+			ETHEndpoint:     "http://localhost:8545",
+			Builders:        []config.BuilderConfig{{Name: "beaver", Addresses: []string{builderAddress.Hex()}}},
+		}, blockMetrics, newTestLogger())
+		if err != nil {
+			t.Fatalf("Failed to create processor: %v", err)
+		}
+
+		processor.trackBuilder(block("", transfer(builderKey, feeRecipient, 5e15)))
+		processor.trackBuilder(block(""))
+
+		for builder, want := range map[string]float64{"beaver": 1, BuilderLocal: 1, BuilderUnknown: 0} {
+			if got := testutil.ToFloat64(blockMetrics.BuilderBlocks.WithLabelValues("validator1", builder)); got != want {
+				t.Errorf("Expected %v blocks from %s, got %v", want, builder, got)
+			}
+		}
+		if got := testutil.CollectAndCount(blockMetrics.BuilderPayments); got != 1 {
+			t.Errorf("Expected one payment histogram, got %d", got)
+		}
+	})
+}
+
+func TestCheckExecutionBlocksBuilderBlock(t *testing.T) {
+	ours, other := common.HexToAddress("0xabc"), common.HexToAddress("0xdef")
+	builderKey, _ := crypto.GenerateKey()
+	builderAddress := crypto.PubkeyToAddress(builderKey.PublicKey)
+	strangerKey, _ := crypto.GenerateKey()
+
+	clServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(BlockResponse{Result: BlockResult{
+			BlockID: BlockID{Hash: "hash_100"},
+			Block: Block{
+				Header: BlockHeader{Height: "100", ProposerAddress: "validator1"},
+				Data:   BlockData{Txs: []string{"tx1"}},
+			},
+		}})
+	}))
+	defer clServer.Close()
+
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	pay := func(key *ecdsa.PrivateKey) *types.Transaction {
+		tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), To: &ours, Value: big.NewInt(5e15)}), signer, key)
+		if err != nil {
+			t.Fatalf("Failed to sign transaction: %v", err)
+		}
+		return tx
+	}
+
+	tests := []struct {
+		name        string
+		coinbase    common.Address
+		payer       *ecdsa.PrivateKey
+		wantStatus  proposals.Status
+		wantBuilder string
+	}{
+		{
+			// The builder is the coinbase of every block, and only pays us at 98
+			name:        "registered builder",
+			coinbase:    builderAddress,
+			payer:       builderKey,
+			wantStatus:  proposals.StatusConfirmed,
+			wantBuilder: "beaver",
+		},
+		{
+			// Anyone can pay our fee recipient in another proposer's block
+			name:       "unregistered payer",
+			coinbase:   other,
+			payer:      strangerKey,
+			wantStatus: proposals.StatusMissed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks := map[int64]*types.Block{}
+			for height := int64(96); height <= 100; height++ {
+				block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(height), Coinbase: tt.coinbase})
+				if height == 98 {
+					block = block.WithBody(types.Body{Transactions: []*types.Transaction{pay(tt.payer)}})
+				}
+				blocks[height] = block
+			}
+
+			blockMetrics := metrics.NewBlockMetrics()
+			processor, err := NewBlockProcessor(&config.Config{
+				TargetValidator: "validator1",
+				EVMAddress:      ours.Hex(),
+				ETHEndpoint:     "http://localhost:8545",
+				RPCEndpoint:     clServer.URL,
+				Builders:        []config.BuilderConfig{{Name: "beaver", Addresses: []string{builderAddress.Hex()}}},
+			}, blockMetrics, newTestLogger())
+			if err != nil {
+				t.Fatalf("Failed to create processor: %v", err)
+			}
+			processor.client = &MockEthClient{blocks: blocks}
+			store := proposals.NewMemoryStore(10)
+			processor.SetProposalStore(store)
+
+			if err := processor.checkExecutionBlocks(100, time.Now(), 98); err != nil {
+				t.Fatalf("checkExecutionBlocks() error = %v", err)
+			}
+
+			records, _, _ := store.Query(proposals.Filter{})
+			if len(records) != 1 || records[0].Status != tt.wantStatus {
+				t.Fatalf("Expected the proposal %s, got %+v", tt.wantStatus, records)
+			}
+			if tt.wantStatus == proposals.StatusMissed {
+				if got := testutil.ToFloat64(blockMetrics.ExecutionConfirmed); got != 0 {
+					t.Errorf("Expected no confirmed block, got %v", got)
+				}
+				return
+			}
+
+			if records[0].ELHeight != 98 {
+				t.Errorf("Expected the builder's block at 98 to confirm the proposal, got %d", records[0].ELHeight)
+			}
+			if got := testutil.ToFloat64(blockMetrics.ExecutionMissed.WithLabelValues(string(proposals.MissOtherProposer))); got != 0 {
+				t.Errorf("Expected no other_proposer miss, got %v", got)
+			}
+			if got := testutil.ToFloat64(blockMetrics.BuilderBlocks.WithLabelValues("validator1", tt.wantBuilder)); got != 1 {
+				t.Errorf("Expected the block attributed to %s, got %v", tt.wantBuilder, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	httpClient "cosmos-evm-exporter/internal/http"
+	"cosmos-evm-exporter/internal/logger"
+	"cosmos-evm-exporter/internal/proposals"
+)
+
+const DefaultConsensusPollInterval = 500 * time.Millisecond
+
+// GetRoundState fetches the round state of the height being decided from
+// /consensus_state.
+func GetRoundState(client *httpClient.Client, endpoint string) (RoundState, error) {
+	req, err := http.NewRequest("GET", endpoint+"/consensus_state", nil)
+	if err != nil {
+		return RoundState{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := client.DoRequest(req)
+	if err != nil {
+		return RoundState{}, fmt.Errorf("failed to fetch consensus state: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var state ConsensusStateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&state); err != nil {
+		return RoundState{}, fmt.Errorf("failed to parse consensus state response: %w", err)
+	}
+
+	return state.Result.RoundState, nil
+}
+
+// HeightRound parses the "height/round/step" field.
+func (s RoundState) HeightRound() (int64, int, error) {
+	var height int64
+	var round, step int
+	if _, err := fmt.Sscanf(s.HeightRoundStep, "%d/%d/%d", &height, &round, &step); err != nil {
+		return 0, 0, fmt.Errorf("failed to parse height/round/step %q: %w", s.HeightRoundStep, err)
+	}
+	return height, round, nil
+}
+
+// failedRound is a round whose proposer did not get its block committed.
+type failedRound struct {
+	round    int
+	proposer string
+}
+
+// heightRounds summarizes how a height was decided.
+type heightRounds struct {
+	height int64
+	rounds int
+	failed []failedRound
+}
+
+// roundTracker follows the rounds of the height being decided. A failed
+// proposal round lasts at least timeout_propose, so polling faster than that
+// sees the proposer of every round.
+type roundTracker struct {
+	height    int64
+	maxRound  int
+	proposers map[int]string
+}
+
+// observe records a round state and returns the summary of the previous
+// height once a new height has started.
+func (t *roundTracker) observe(height int64, round int, proposer string) (heightRounds, bool) {
+	var done heightRounds
+	finished := false
+
+	if height != t.height {
+		if t.height > 0 && height > t.height {
+			done = t.summary()
+			finished = true
+		}
+		t.height = height
+		t.maxRound = 0
+		t.proposers = map[int]string{}
+	}
+
+	if round > t.maxRound {
+		t.maxRound = round
+	}
+	if proposer != "" {
+		t.proposers[round] = proposer
+	}
+
+	return done, finished
+}
+
+// summary attributes every round before the last one to its proposer.
+func (t *roundTracker) summary() heightRounds {
+	summary := heightRounds{height: t.height, rounds: t.maxRound + 1}
+	for round, proposer := range t.proposers {
+		if round < t.maxRound {
+			summary.failed = append(summary.failed, failedRound{round: round, proposer: proposer})
+		}
+	}
+	sort.Slice(summary.failed, func(i, j int) bool {
+		return summary.failed[i].round < summary.failed[j].round
+	})
+	return summary
+}
+
+// StartConsensusTracker polls /consensus_state every interval until ctx is
+// done, tracking how many rounds each height needs and whose proposal rounds
+// failed.
+func (p *BlockProcessor) StartConsensusTracker(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultConsensusPollInterval
+	}
+
+	go func() {
+		client := httpClient.NewClient()
+		tracker := &roundTracker{}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				state, err := GetRoundState(client, p.config.RPCEndpoint)
+				if err == nil {
+					var height int64
+					var round int
+					height, round, err = state.HeightRound()
+					if err == nil {
+						p.metrics.ConsensusRound.Set(float64(round))
+						if done, ok := tracker.observe(height, round, state.Proposer.Address); ok {
+							// Fetching the committed block may retry for a
+							// while, so it must not hold up the polling
+							go p.finishHeight(done)
+						}
+					}
+				}
+				if err != nil {
+					p.rpcLogger.WriteJSONLog(logger.LevelError, "Failed to get consensus state", nil, err)
+					p.metrics.Errors.Inc()
+				}
+				time.Sleep(interval)
+			}
+		}
+	}()
+}
+
+// finishHeight updates the round metrics for a decided height and records a
+// missed proposal when one of the failed rounds was ours. When our validator
+// still proposed the committed block in a later round, the processor records
+// the height from that block and its record is the one kept.
+func (p *BlockProcessor) finishHeight(done heightRounds) {
+	p.metrics.RoundsPerHeight.Observe(float64(done.rounds))
+	if done.rounds > 1 {
+		p.consensusLogger.WriteJSONLog(logger.LevelInfo, "Height needed more than one round", map[string]interface{}{
+			"height": done.height,
+			"rounds": done.rounds,
+		}, nil)
+	}
+
+	ourRound := -1
+	for _, failed := range done.failed {
+		p.metrics.FailedProposals.WithLabelValues(failed.proposer).Inc()
+		if failed.proposer == p.config.TargetValidator && ourRound < 0 {
+			ourRound = failed.round
+		}
+	}
+	if ourRound < 0 {
+		return
+	}
+
+	record := proposals.Record{
+		Validator:  p.config.TargetValidator,
+		CLHeight:   done.height,
+		Status:     proposals.StatusMissed,
+		MissReason: proposals.MissRoundFailed,
+		BlockTime:  time.Now().UTC(),
+		ObservedAt: time.Now().UTC(),
+	}
+	block, err := GetBlock(httpClient.NewClient(), p.config.RPCEndpoint, done.height)
+	if err != nil {
+		p.rpcLogger.WriteJSONLog(logger.LevelWarn, "Failed to get block of failed proposal round", map[string]interface{}{
+			"height": done.height,
+		}, err)
+	} else {
+		if block.Result.Block.Header.ProposerAddress == p.config.TargetValidator {
+			return // Left to the processor
+		}
+		record.BlockTime = block.Result.Block.Header.Time
+	}
+
+	// Account the failed round like any other proposal of ours
+	p.metrics.TotalProposed.Inc()
+	p.metrics.ExecutionMissed.WithLabelValues(string(proposals.MissRoundFailed)).Inc()
+	p.consensusLogger.WriteJSONLog(logger.LevelWarn, "Proposal round failed", map[string]interface{}{
+		"height": done.height,
+		"round":  ourRound,
+		"reason": proposals.MissRoundFailed,
+	}, nil)
+	p.recordProposal(record)
+}
@@ -0,0 +1,148 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cosmos-evm-exporter/internal/config"
+	httpClient "cosmos-evm-exporter/internal/http"
+	"cosmos-evm-exporter/internal/metrics"
+	"cosmos-evm-exporter/internal/proposals"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestGetRoundState(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/consensus_state" {
+			t.Errorf("Expected path '/consensus_state', got %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","id":-1,"result":{"round_state":{
+			"height/round/step":"7458296/2/3",
+			"start_time":"2024-11-15T10:53:34.000Z",
+			"proposer":{"address":"B2A5C37E","index":4}}}}`))
+	}))
+	defer server.Close()
+
+	state, err := GetRoundState(httpClient.NewClient(), server.URL)
+	if err != nil {
+		t.Fatalf("GetRoundState() error = %v", err)
+	}
+	height, round, err := state.HeightRound()
+	if err != nil {
+		t.Fatalf("HeightRound() error = %v", err)
+	}
+	if height != 7458296 || round != 2 || state.Proposer.Address != "B2A5C37E" {
+		t.Errorf("Unexpected round state: height=%d round=%d proposer=%s", height, round, state.Proposer.Address)
+	}
+}
+
+func TestRoundTracker(t *testing.T) {
+	tracker := &roundTracker{}
+
+	steps := []struct {
+		height   int64
+		round    int
+		proposer string
+	}{
+		{100, 0, "val1"},
+		{100, 0, "val1"},
+		{101, 0, "val2"},
+		{101, 1, "val3"},
+		{101, 2, "val1"},
+		{102, 0, "val2"},
+	}
+
+	var done []heightRounds
+	for _, step := range steps {
+		if summary, ok := tracker.observe(step.height, step.round, step.proposer); ok {
+			done = append(done, summary)
+		}
+	}
+
+	if len(done) != 2 {
+		t.Fatalf("Expected 2 finished heights, got %d", len(done))
+	}
+	if done[0].height != 100 || done[0].rounds != 1 || len(done[0].failed) != 0 {
+		t.Errorf("Unexpected summary for height 100: %+v", done[0])
+	}
+	if done[1].height != 101 || done[1].rounds != 3 || len(done[1].failed) != 2 {
+		t.Fatalf("Unexpected summary for height 101: %+v", done[1])
+	}
+	if done[1].failed[0].proposer != "val2" || done[1].failed[1].proposer != "val3" {
+		t.Errorf("Expected rounds 0 and 1 to be attributed to val2 and val3, got %+v", done[1].failed)
+	}
+}
+
+func TestFinishHeight(t *testing.T) {
+	blockTime := time.Date(2024, 11, 15, 10, 53, 34, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		committer  string
+		wantRecord bool
+	}{
+		{name: "won by another validator", committer: "val1", wantRecord: true},
+		// Our block committed in a later round is the processor's to record
+		{name: "won by our later round", committer: "val2", wantRecord: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(BlockResponse{Result: BlockResult{
+					BlockID: BlockID{Hash: "hash_101"},
+					Block:   Block{Header: BlockHeader{Height: "101", ProposerAddress: tt.committer, Time: blockTime}},
+				}})
+			}))
+			defer clServer.Close()
+
+			metrics := metrics.NewBlockMetrics()
+			processor, err := NewBlockProcessor(&config.Config{
+				TargetValidator: "val2",
+				ETHEndpoint:     "http://localhost:8545",
+				RPCEndpoint:     clServer.URL,
+			}, metrics, newTestLogger())
+			if err != nil {
+				t.Fatalf("Failed to create processor: %v", err)
+			}
+			store := proposals.NewMemoryStore(10)
+			processor.SetProposalStore(store)
+
+			processor.finishHeight(heightRounds{
+				height: 101,
+				rounds: 3,
+				failed: []failedRound{{round: 0, proposer: "val2"}, {round: 1, proposer: "val3"}},
+			})
+
+			if got := testutil.ToFloat64(metrics.FailedProposals.WithLabelValues("val3")); got != 1 {
+				t.Errorf("Expected 1 failed round for val3, got %v", got)
+			}
+
+			var want float64
+			if tt.wantRecord {
+				want = 1
+			}
+			if got := testutil.ToFloat64(metrics.ExecutionMissed.WithLabelValues(string(proposals.MissRoundFailed))); got != want {
+				t.Errorf("Expected %v round_failed misses, got %v", want, got)
+			}
+			if got := testutil.ToFloat64(metrics.TotalProposed); got != want {
+				t.Errorf("Expected %v proposals, got %v", want, got)
+			}
+
+			records, _, _ := store.Query(proposals.Filter{})
+			if !tt.wantRecord {
+				if len(records) != 0 {
+					t.Errorf("Expected no record, got %+v", records)
+				}
+				return
+			}
+			if len(records) != 1 || records[0].MissReason != proposals.MissRoundFailed || !records[0].BlockTime.Equal(blockTime) {
+				t.Errorf("Expected a round_failed record for height 101 at the block time, got %+v", records)
+			}
+		})
+	}
+}
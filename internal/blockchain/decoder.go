@@ -4,20 +4,87 @@ import (
 	"cosmos-evm-exporter/internal/logger"
 	"encoding/base64"
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 )
 
-func DecodeTx(txBase64 string, logger *logger.Logger) (*EVMChainTx, error) {
+// Message types of framed CL transactions with a built-in decoder.
+const (
+	MsgTypeExecutionPayload uint32 = 1
+	MsgTypeDeposits         uint32 = 2
+	MsgTypeBlobSidecars     uint32 = 3
+	MsgTypeValidatorUpdates uint32 = 4
+)
+
+// TxTypeCosmosSDK is the type of unframed, standard Cosmos SDK transactions.
+const TxTypeCosmosSDK = "cosmos_sdk"
+
+// TxDecoder turns the payload of one message type into a typed value.
+type TxDecoder struct {
+	Name   string
+	Decode func(payload []byte) (interface{}, error)
+}
+
+var txDecoders = map[uint32]TxDecoder{
+	MsgTypeExecutionPayload: {Name: "execution_payload", Decode: decodeExecutionPayload},
+	MsgTypeDeposits:         {Name: "deposits", Decode: decodeDeposits},
+	MsgTypeBlobSidecars:     {Name: "blob_sidecars", Decode: decodeBlobSidecars},
+	MsgTypeValidatorUpdates: {Name: "validator_updates", Decode: decodeValidatorUpdates},
+}
+
+// RegisterTxDecoder adds or replaces the decoder of msgType. It is not safe
+// to call while blocks are being processed.
+func RegisterTxDecoder(msgType uint32, name string, decode func(payload []byte) (interface{}, error)) {
+	txDecoders[msgType] = TxDecoder{Name: name, Decode: decode}
+}
+
+// TxTypes returns the names of every registered decoder and the Cosmos SDK
+// type, sorted.
+func TxTypes() []string {
+	names := []string{TxTypeCosmosSDK}
+	for _, decoder := range txDecoders {
+		names = append(names, decoder.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DecodedTx is a CL transaction decoded into its typed value.
+type DecodedTx struct {
+	MsgType uint32 // Zero for Cosmos SDK transactions
+	Type    string
+	Value   interface{}
+}
+
+// UnknownMsgTypeError is returned for a framed transaction whose message type
+// has no decoder.
+type UnknownMsgTypeError struct {
+	MsgType uint32
+}
+
+func (e *UnknownMsgTypeError) Error() string {
+	return fmt.Sprintf("unknown message type %d", e.MsgType)
+}
+
+func DecodeTx(txBase64 string, log *logger.Logger) (*EVMChainTx, error) {
 	// Decode base64
 	txData, err := base64.StdEncoding.DecodeString(txBase64)
 	if err != nil {
 		return nil, fmt.Errorf("base64 decode failed: %v", err)
 	}
 
-	logger.WriteJSONLog("debug", "Raw tx data", map[string]interface{}{
+	log.WriteJSONLog(logger.LevelDebug, "Raw tx data", map[string]interface{}{
 		"length": len(txData),
 	}, nil)
 
+	return parseFrame(txData)
+}
+
+// parseFrame splits a framed transaction into its message type, length and
+// payload.
+func parseFrame(txData []byte) (*EVMChainTx, error) {
 	if len(txData) < 8 {
 		return nil, fmt.Errorf("tx data too short: %d bytes", len(txData))
 	}
@@ -36,3 +103,77 @@ func DecodeTx(txBase64 string, logger *logger.Logger) (*EVMChainTx, error) {
 
 	return tx, nil
 }
+
+// DecodeCLTx decodes a CL transaction with the decoder registered for its
+// message type. Transactions that are not framed, or framed with an unknown
+// type, are tried as Cosmos SDK transactions before giving up with an
+// UnknownMsgTypeError.
+func DecodeCLTx(txBase64 string) (*DecodedTx, error) {
+	txData, err := base64.StdEncoding.DecodeString(txBase64)
+	if err != nil {
+		return nil, fmt.Errorf("base64 decode failed: %v", err)
+	}
+
+	frame, frameErr := parseFrame(txData)
+	if frameErr == nil {
+		if decoder, ok := txDecoders[frame.MsgType]; ok {
+			value, err := decoder.Decode(frame.Payload)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode %s: %w", decoder.Name, err)
+			}
+			return &DecodedTx{MsgType: frame.MsgType, Type: decoder.Name, Value: value}, nil
+		}
+	}
+
+	if tx, err := DecodeCosmosTx(txData); err == nil {
+		return &DecodedTx{Type: TxTypeCosmosSDK, Value: tx}, nil
+	}
+
+	if frameErr != nil {
+		return nil, frameErr
+	}
+	return nil, &UnknownMsgTypeError{MsgType: frame.MsgType}
+}
+
+// decodeTxs decodes every transaction of block, counting them by type.
+// Unknown message types usually mean the protocol changed in an upgrade, so
+// each one is logged the first time it shows up.
+func (p *BlockProcessor) decodeTxs(block *BlockResponse) {
+	height := block.Result.Block.Header.Height
+
+	for i, txBase64 := range block.Result.Block.Data.Txs {
+		tx, err := DecodeCLTx(txBase64)
+		if err == nil {
+			p.metrics.CLTxs.WithLabelValues(tx.Type).Inc()
+			p.decoderLogger.WriteJSONLog(logger.LevelDebug, "Decoded transaction", map[string]interface{}{
+				"height": height,
+				"index":  i,
+				"type":   tx.Type,
+			}, nil)
+			continue
+		}
+
+		var unknown *UnknownMsgTypeError
+		if errors.As(err, &unknown) {
+			p.metrics.UnknownCLTxs.WithLabelValues(strconv.FormatUint(uint64(unknown.MsgType), 10)).Inc()
+			if !p.unknownMsgTypes[unknown.MsgType] {
+				p.unknownMsgTypes[unknown.MsgType] = true
+				p.decoderLogger.WriteJSONLog(logger.LevelWarn, "Unknown transaction message type", map[string]interface{}{
+					"height":   height,
+					"index":    i,
+					"msg_type": unknown.MsgType,
+				}, nil)
+			}
+		} else {
+			p.metrics.CLTxDecodeErrors.Inc()
+			p.decoderLogger.WriteJSONLog(logger.LevelWarn, "Failed to decode transaction", map[string]interface{}{
+				"height": height,
+				"index":  i,
+			}, err)
+		}
+
+		if raw, err := base64.StdEncoding.DecodeString(txBase64); err == nil {
+			p.DumpPayload(raw)
+		}
+	}
+}
@@ -3,9 +3,18 @@ package blockchain
 import (
 	"encoding/base64"
 	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math/big"
 	"testing"
 
+	"cosmos-evm-exporter/internal/config"
 	"cosmos-evm-exporter/internal/logger"
+	"cosmos-evm-exporter/internal/metrics"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/protobuf/encoding/protowire"
 )
 
 func TestDecodeTx(t *testing.T) {
@@ -94,3 +103,155 @@ func TestDecodeTx(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeCLTx(t *testing.T) {
+	frame := func(msgType uint32, payload string) string {
+		data := make([]byte, 8+len(payload))
+		binary.BigEndian.PutUint32(data[0:4], msgType)
+		binary.BigEndian.PutUint32(data[4:8], uint32(len(payload)))
+		copy(data[8:], payload)
+		return base64.StdEncoding.EncodeToString(data)
+	}
+
+	payload, err := json.Marshal(engine.ExecutableData{
+		Number:        100,
+		BaseFeePerGas: big.NewInt(7),
+		LogsBloom:     make([]byte, 256),
+		ExtraData:     []byte{},
+		Transactions:  [][]byte{},
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode payload: %v", err)
+	}
+
+	// A TxRaw with a single MsgSend and one signature
+	var anyMsg, body, txRaw []byte
+	anyMsg = protowire.AppendTag(anyMsg, 1, protowire.BytesType)
+	anyMsg = protowire.AppendString(anyMsg, "/cosmos.bank.v1beta1.MsgSend")
+	anyMsg = protowire.AppendTag(anyMsg, 2, protowire.BytesType)
+	anyMsg = protowire.AppendBytes(anyMsg, []byte{0x0a, 0x01, 0x61})
+	body = protowire.AppendTag(body, 1, protowire.BytesType)
+	body = protowire.AppendBytes(body, anyMsg)
+	body = protowire.AppendTag(body, 2, protowire.BytesType)
+	body = protowire.AppendString(body, "hello")
+	txRaw = protowire.AppendTag(txRaw, 1, protowire.BytesType)
+	txRaw = protowire.AppendBytes(txRaw, body)
+	txRaw = protowire.AppendTag(txRaw, 2, protowire.BytesType)
+	txRaw = protowire.AppendBytes(txRaw, []byte{})
+	txRaw = protowire.AppendTag(txRaw, 3, protowire.BytesType)
+	txRaw = protowire.AppendBytes(txRaw, make([]byte, 64))
+
+	tests := []struct {
+		name        string
+		input       string
+		wantType    string
+		wantUnknown uint32
+		wantErr     bool
+	}{
+		{
+			name:     "execution payload",
+			input:    frame(MsgTypeExecutionPayload, string(payload)),
+			wantType: "execution_payload",
+		},
+		{
+			name:     "deposits",
+			input:    frame(MsgTypeDeposits, `[{"pubkey":"0x01","amount":"32000000000","index":"7"}]`),
+			wantType: "deposits",
+		},
+		{
+			name:     "blob sidecars",
+			input:    frame(MsgTypeBlobSidecars, `[{"index":"1","blob":"0x0102","kzg_commitment":"0x03","kzg_proof":"0x04"}]`),
+			wantType: "blob_sidecars",
+		},
+		{
+			name:     "validator updates",
+			input:    frame(MsgTypeValidatorUpdates, `[{"pub_key":"0x02","power":"0"}]`),
+			wantType: "validator_updates",
+		},
+		{
+			name:     "cosmos sdk transaction",
+			input:    base64.StdEncoding.EncodeToString(txRaw),
+			wantType: TxTypeCosmosSDK,
+		},
+		{
+			name:        "unknown message type",
+			input:       frame(99, "opaque"),
+			wantUnknown: 99,
+			wantErr:     true,
+		},
+		{
+			name:    "malformed known type",
+			input:   frame(MsgTypeBlobSidecars, "not json"),
+			wantErr: true,
+		},
+		{
+			name:    "not base64",
+			input:   "tx1",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeCLTx(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeCLTx() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var unknown *UnknownMsgTypeError
+			if tt.wantUnknown != 0 && (!errors.As(err, &unknown) || unknown.MsgType != tt.wantUnknown) {
+				t.Errorf("Expected unknown message type %d, got %v", tt.wantUnknown, err)
+			}
+			if err == nil && got.Type != tt.wantType {
+				t.Errorf("Type = %s, want %s", got.Type, tt.wantType)
+			}
+		})
+	}
+
+	got, _ := DecodeCLTx(frame(MsgTypeDeposits, `[{"pubkey":"0x01","amount":"32000000000","index":"7"}]`))
+	if deposits := got.Value.([]Deposit); deposits[0].Amount != 32000000000 || deposits[0].Index != 7 {
+		t.Errorf("Unexpected deposits %+v", deposits)
+	}
+	got, _ = DecodeCLTx(frame(MsgTypeBlobSidecars, `[{"index":"1","blob":"0x0102","kzg_commitment":"0x03","kzg_proof":"0x04"}]`))
+	if sidecars := got.Value.([]BlobSidecar); sidecars[0].Index != 1 || len(sidecars[0].Blob) != 2 || sidecars[0].KZGCommitment[0] != 3 {
+		t.Errorf("Unexpected blob sidecars %+v", sidecars)
+	}
+	got, _ = DecodeCLTx(frame(MsgTypeValidatorUpdates, `[{"pub_key":"0x02","power":"10"}]`))
+	if updates := got.Value.([]ValidatorUpdate); updates[0].PubKey[0] != 2 || updates[0].Power != 10 {
+		t.Errorf("Unexpected validator updates %+v", updates)
+	}
+	got, _ = DecodeCLTx(frame(MsgTypeExecutionPayload, string(payload)))
+	if payload := got.Value.(*engine.ExecutableData); payload.Number != 100 {
+		t.Errorf("Unexpected execution payload %+v", payload)
+	}
+	got, _ = DecodeCLTx(base64.StdEncoding.EncodeToString(txRaw))
+	if tx := got.Value.(*CosmosTx); tx.Memo != "hello" || tx.Signatures != 1 || tx.Messages[0].TypeURL != "/cosmos.bank.v1beta1.MsgSend" {
+		t.Errorf("Unexpected Cosmos SDK transaction %+v", tx)
+	}
+}
+
+func TestDecodeTxsCountsUnknownTypes(t *testing.T) {
+	metrics := metrics.NewBlockMetrics()
+	processor, err := NewBlockProcessor(&config.Config{ETHEndpoint: "http://localhost:8545"}, metrics, newTestLogger())
+	if err != nil {
+		t.Fatalf("Failed to create processor: %v", err)
+	}
+
+	unknown := make([]byte, 8)
+	binary.BigEndian.PutUint32(unknown[0:4], 42)
+	tx := base64.StdEncoding.EncodeToString(unknown)
+
+	processor.decodeTxs(&BlockResponse{Result: BlockResult{Block: Block{
+		Data: BlockData{Txs: []string{tx, tx, "tx1"}},
+	}}})
+
+	if got := testutil.ToFloat64(metrics.UnknownCLTxs.WithLabelValues("42")); got != 2 {
+		t.Errorf("Expected 2 transactions of unknown type 42, got %v", got)
+	}
+	if got := testutil.ToFloat64(metrics.CLTxDecodeErrors); got != 1 {
+		t.Errorf("Expected 1 decode error, got %v", got)
+	}
+	if got := testutil.ToFloat64(metrics.CLTxs.WithLabelValues("execution_payload")); got != 0 {
+		t.Errorf("Expected no execution payloads, got %v", got)
+	}
+}
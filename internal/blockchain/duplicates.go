@@ -0,0 +1,124 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	httpClient "cosmos-evm-exporter/internal/http"
+	"cosmos-evm-exporter/internal/logger"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// elMatchHistory is how many EL heights below the latest match are kept to
+// detect a block matched by two proposals.
+const elMatchHistory = 1000
+
+// elMatch is an EL block matched to one of our proposals.
+type elMatch struct {
+	hash     string
+	clHeight int64
+}
+
+// checkSharedELBlock remembers that the EL block at elHeight was matched to
+// clHeight, and flags it when another proposal already claimed it. Proposals
+// carrying a payload claim its block before the scan, since the scan never
+// goes back to an EL height already matched.
+func (p *BlockProcessor) checkSharedELBlock(clHeight, elHeight int64, hash string) {
+	if previous, ok := p.elMatches[elHeight]; ok && previous.hash == hash && previous.clHeight != clHeight {
+		p.metrics.SharedELBlocks.Inc()
+		p.logger.WriteJSONLog(logger.LevelWarn, "Execution block matched by several proposals", map[string]interface{}{
+			"el_height":  elHeight,
+			"hash":       hash,
+			"cl_heights": []int64{previous.clHeight, clHeight},
+		}, nil)
+	}
+	p.elMatches[elHeight] = elMatch{hash: hash, clHeight: clHeight}
+
+	for height := range p.elMatches {
+		if height < elHeight-elMatchHistory {
+			delete(p.elMatches, height)
+		}
+	}
+}
+
+// findExtraELBlocks returns the further blocks of ours in the rest
+// of the scan window after the match. The window already stops at the EL head,
+// and a block not found is not produced yet. These fetches only look for
+// duplicates, so their failures are logged without counting as errors.
+func (p *BlockProcessor) findExtraELBlocks(scan scanResult) []*types.Block {
+	var extra []*types.Block
+	for height := scan.height + 1; height <= scan.end; height++ {
+		elBlock, err := p.client.BlockByNumber(context.Background(), big.NewInt(height))
+		if errors.Is(err, ethereum.NotFound) {
+			break
+		}
+		if err != nil {
+			p.rpcLogger.WriteJSONLog(logger.LevelWarn, "Failed to fetch block", map[string]interface{}{
+				"height": height,
+			}, err)
+			continue
+		}
+		p.headers.add(elBlock.Header())
+		if p.isOurBlock(elBlock) {
+			p.headers.markOurs(height)
+			extra = append(extra, elBlock)
+		}
+	}
+	return extra
+}
+
+// checkExtraELBlocks flags the further blocks with our coinbase found after
+// the match. CL and EL heights advance together, so such a block belongs to
+// the CL height at the same distance from clHeight. It is expected when we
+// proposed that height too, and a duplicate otherwise. Heights the consensus
+// node has not reached yet are left undecided.
+func (p *BlockProcessor) checkExtraELBlocks(clHeight int64, scan scanResult) {
+	if len(scan.extra) == 0 {
+		return
+	}
+
+	current, err := p.GetCurrentHeight()
+	if err != nil {
+		p.rpcLogger.WriteJSONLog(logger.LevelWarn, "Failed to get current height", nil, err)
+		return
+	}
+
+	client := httpClient.NewClient()
+	var heights []int64
+	var hashes []string
+	for _, extra := range scan.extra {
+		elHeight := extra.Number().Int64()
+		otherCL := clHeight + elHeight - scan.height
+		if otherCL > current {
+			continue
+		}
+
+		block, err := GetBlock(client, p.config.RPCEndpoint, otherCL)
+		if err != nil {
+			p.rpcLogger.WriteJSONLog(logger.LevelWarn, "Failed to get block", map[string]interface{}{
+				"height": otherCL,
+			}, err)
+			continue
+		}
+		if block.Result.Block.Header.ProposerAddress == p.config.TargetValidator {
+			continue // Our next proposal
+		}
+
+		heights = append(heights, elHeight)
+		hashes = append(hashes, extra.Hash().Hex())
+	}
+
+	if len(heights) == 0 {
+		return
+	}
+	p.metrics.ProposalsWithMultipleELBlocks.Inc()
+	p.logger.WriteJSONLog(logger.LevelWarn, "Proposal has several execution blocks", map[string]interface{}{
+		"cl_height":        clHeight,
+		"el_height":        scan.height,
+		"extra_el_heights": heights,
+		"extra_hashes":     hashes,
+	}, nil)
+}
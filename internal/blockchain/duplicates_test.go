@@ -0,0 +1,169 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/metrics"
+	"cosmos-evm-exporter/internal/proposals"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCheckSharedELBlock(t *testing.T) {
+	metrics := metrics.NewBlockMetrics()
+	processor, err := NewBlockProcessor(&config.Config{ETHEndpoint: "http://localhost:8545"}, metrics, newTestLogger())
+	if err != nil {
+		t.Fatalf("Failed to create processor: %v", err)
+	}
+
+	processor.checkSharedELBlock(100, 50, "0xaa")
+	processor.checkSharedELBlock(100, 50, "0xaa") // Same proposal processed again
+	processor.checkSharedELBlock(101, 51, "0xbb")
+	processor.checkSharedELBlock(102, 51, "0xbb") // Claimed twice
+
+	if got := testutil.ToFloat64(metrics.SharedELBlocks); got != 1 {
+		t.Errorf("Expected 1 shared EL block, got %v", got)
+	}
+
+	// Old matches are forgotten
+	processor.checkSharedELBlock(2000, 1100, "0xcc")
+	if _, ok := processor.elMatches[50]; ok {
+		t.Error("Expected matches more than 1000 EL heights back to be pruned")
+	}
+}
+
+func TestCheckExecutionBlocksSharedELBlock(t *testing.T) {
+	ours, other := common.HexToAddress("0xabc"), common.HexToAddress("0xdef")
+	blocks := map[int64]*types.Block{}
+	for height := int64(48); height <= 56; height++ {
+		coinbase := other
+		if height == 50 {
+			coinbase = ours
+		}
+		blocks[height] = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(height), Coinbase: coinbase})
+	}
+
+	payload, err := json.Marshal(engine.ExecutableData{
+		ParentHash:    blocks[50].ParentHash(),
+		FeeRecipient:  ours,
+		StateRoot:     blocks[50].Root(),
+		BlockHash:     blocks[50].Hash(),
+		Number:        50,
+		BaseFeePerGas: big.NewInt(0),
+		LogsBloom:     make([]byte, 256),
+		ExtraData:     []byte{},
+		Transactions:  [][]byte{},
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode payload: %v", err)
+	}
+	frame := make([]byte, 8+len(payload))
+	binary.BigEndian.PutUint32(frame[0:4], MsgTypeExecutionPayload)
+	binary.BigEndian.PutUint32(frame[4:8], uint32(len(payload)))
+	copy(frame[8:], payload)
+	payloadTx := base64.StdEncoding.EncodeToString(frame)
+
+	// Both proposals carry the payload of EL block 50
+	clServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		height := r.URL.Query().Get("height")
+		json.NewEncoder(w).Encode(BlockResponse{Result: BlockResult{
+			BlockID: BlockID{Hash: "hash_" + height},
+			Block: Block{
+				Header: BlockHeader{Height: height, ProposerAddress: "validator1"},
+				Data:   BlockData{Txs: []string{payloadTx}},
+			},
+		}})
+	}))
+	defer clServer.Close()
+
+	blockMetrics := metrics.NewBlockMetrics()
+	processor, err := NewBlockProcessor(&config.Config{
+		TargetValidator: "validator1",
+		EVMAddress:      ours.Hex(),
+		ETHEndpoint:     "http://localhost:8545",
+		RPCEndpoint:     clServer.URL,
+	}, blockMetrics, newTestLogger())
+	if err != nil {
+		t.Fatalf("Failed to create processor: %v", err)
+	}
+	processor.client = &MockEthClient{blocks: blocks, head: 56}
+	store := proposals.NewMemoryStore(10)
+	processor.SetProposalStore(store)
+
+	for _, clHeight := range []int64{100, 101} {
+		if err := processor.checkExecutionBlocks(clHeight, time.Now(), 50); err != nil {
+			t.Fatalf("checkExecutionBlocks(%d) error = %v", clHeight, err)
+		}
+	}
+
+	if got := testutil.ToFloat64(blockMetrics.SharedELBlocks); got != 1 {
+		t.Errorf("Expected 1 shared EL block, got %v", got)
+	}
+	// The second proposal's scan starts past the block already matched
+	records, _, _ := store.Query(proposals.Filter{})
+	if len(records) != 2 || records[0].CLHeight != 101 || records[0].Status != proposals.StatusMissed {
+		t.Errorf("Expected the second proposal missed, got %+v", records)
+	}
+}
+
+func TestCheckExtraELBlocks(t *testing.T) {
+	proposers := map[string]string{"101": "validator1", "102": "validator2"}
+	clServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/status":
+			json.NewEncoder(w).Encode(StatusResponse{Result: StatusResult{SyncInfo: SyncInfo{LatestBlockHeight: "110"}}})
+		case "/block":
+			height := r.URL.Query().Get("height")
+			json.NewEncoder(w).Encode(BlockResponse{Result: BlockResult{
+				BlockID: BlockID{Hash: "hash_" + height},
+				Block:   Block{Header: BlockHeader{Height: height, ProposerAddress: proposers[height]}},
+			}})
+		}
+	}))
+	defer clServer.Close()
+
+	metrics := metrics.NewBlockMetrics()
+	processor, err := NewBlockProcessor(&config.Config{
+		TargetValidator: "validator1",
+		ETHEndpoint:     "http://localhost:8545",
+		RPCEndpoint:     clServer.URL,
+	}, metrics, newTestLogger())
+	if err != nil {
+		t.Fatalf("Failed to create processor: %v", err)
+	}
+
+	ours := common.HexToAddress("0xabc")
+	block := func(height int64) *types.Block {
+		return types.NewBlockWithHeader(&types.Header{Number: big.NewInt(height), Coinbase: ours})
+	}
+
+	processor.checkExtraELBlocks(100, scanResult{
+		block:  block(50),
+		height: 50,
+		extra: []*types.Block{
+			block(51), // CL 101, our next proposal
+			block(52), // CL 102, another proposer's height
+			block(70), // CL 120, not reached yet
+		},
+	})
+
+	if got := testutil.ToFloat64(metrics.ProposalsWithMultipleELBlocks); got != 1 {
+		t.Errorf("Expected 1 proposal with multiple EL blocks, got %v", got)
+	}
+
+	processor.checkExtraELBlocks(100, scanResult{block: block(50), height: 50, extra: []*types.Block{block(51)}})
+	if got := testutil.ToFloat64(metrics.ProposalsWithMultipleELBlocks); got != 1 {
+		t.Errorf("Expected consecutive proposals not to count, got %v", got)
+	}
+}
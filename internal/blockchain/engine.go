@@ -0,0 +1,81 @@
+package blockchain
+
+import (
+	"context"
+	"time"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/engine"
+	"cosmos-evm-exporter/internal/logger"
+	"cosmos-evm-exporter/internal/metrics"
+)
+
+// EngineClient is the part of the Engine API used by the probe.
+type EngineClient interface {
+	ExchangeCapabilities(ctx context.Context) ([]string, error)
+	Syncing(ctx context.Context) (bool, error)
+}
+
+// EngineMonitor probes the Engine API of the execution client, the link the
+// consensus client drives block production through.
+type EngineMonitor struct {
+	metrics *metrics.EngineMetrics
+	logger  *logger.Logger
+	client  EngineClient
+}
+
+func NewEngineMonitor(config *config.Config, metrics *metrics.EngineMetrics, logger *logger.Logger) (*EngineMonitor, error) {
+	secret, err := engine.LoadJWTSecret(config.EngineJWTSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EngineMonitor{
+		metrics: metrics,
+		logger:  logger.Component("engine"),
+		client:  engine.NewClient(config.EngineEndpoint, secret),
+	}, nil
+}
+
+// Start probes the Engine API every interval until ctx is done.
+func (m *EngineMonitor) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				m.probe(ctx)
+				time.Sleep(interval)
+			}
+		}
+	}()
+}
+
+func (m *EngineMonitor) probe(ctx context.Context) {
+	start := time.Now()
+	capabilities, err := m.client.ExchangeCapabilities(ctx)
+	if err != nil {
+		m.metrics.Up.Set(0)
+		m.logger.WriteJSONLog(logger.LevelError, "Engine API probe failed", nil, err)
+		return
+	}
+	m.metrics.Latency.Set(time.Since(start).Seconds())
+	m.metrics.Up.Set(1)
+
+	m.metrics.Capabilities.Reset()
+	for _, method := range capabilities {
+		m.metrics.Capabilities.WithLabelValues(method).Set(1)
+	}
+
+	syncing, err := m.client.Syncing(ctx)
+	if err != nil {
+		m.logger.WriteJSONLog(logger.LevelWarn, "Engine API eth_syncing failed", nil, err)
+		return
+	}
+	if syncing {
+		m.metrics.Syncing.Set(1)
+	} else {
+		m.metrics.Syncing.Set(0)
+	}
+}
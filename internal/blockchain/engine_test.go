@@ -0,0 +1,66 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestEngineMonitor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req struct {
+			ID     int    `json:"id"`
+			Method string `json:"method"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var result interface{} = false
+		if req.Method == "engine_exchangeCapabilities" {
+			result = []string{"engine_newPayloadV3", "engine_getPayloadV3"}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	defer server.Close()
+
+	secretFile := filepath.Join(t.TempDir(), "jwt.hex")
+	os.WriteFile(secretFile, []byte(strings.Repeat("ab", 32)), 0600)
+
+	engineMetrics := metrics.NewEngineMetrics(prometheus.NewRegistry())
+	monitor, err := NewEngineMonitor(&config.Config{
+		EngineEndpoint:  server.URL,
+		EngineJWTSecret: secretFile,
+	}, engineMetrics, newTestLogger())
+	if err != nil {
+		t.Fatalf("NewEngineMonitor() error = %v", err)
+	}
+
+	monitor.probe(context.Background())
+
+	if got := testutil.ToFloat64(engineMetrics.Up); got != 1 {
+		t.Errorf("Expected engine API up, got %v", got)
+	}
+	if got := testutil.CollectAndCount(engineMetrics.Capabilities); got != 2 {
+		t.Errorf("Expected 2 capabilities, got %d", got)
+	}
+
+	server.Close()
+	monitor.probe(context.Background())
+	if got := testutil.ToFloat64(engineMetrics.Up); got != 0 {
+		t.Errorf("Expected engine API down after the server stopped, got %v", got)
+	}
+}
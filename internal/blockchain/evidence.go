@@ -0,0 +1,119 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/logger"
+)
+
+// offenderKinds are the evidence kinds that name their offenders.
+var offenderKinds = []string{"duplicate_vote", "light_client_attack"}
+
+// offender is a validator named by a piece of evidence.
+type offender struct {
+	address string
+	height  string
+}
+
+// evidenceKind returns the metric label of an evidence type.
+func evidenceKind(evidenceType string) string {
+	switch evidenceType {
+	case EvidenceTypeDuplicateVote:
+		return "duplicate_vote"
+	case EvidenceTypeLightClientAttack:
+		return "light_client_attack"
+	}
+	return "other"
+}
+
+// offenders returns the validators the evidence accuses.
+func (e Evidence) offenders() ([]offender, error) {
+	switch e.Type {
+	case EvidenceTypeDuplicateVote:
+		var evidence DuplicateVoteEvidence
+		if err := json.Unmarshal(e.Value, &evidence); err != nil {
+			return nil, fmt.Errorf("failed to parse duplicate vote evidence: %w", err)
+		}
+		return []offender{{address: evidence.VoteA.ValidatorAddress, height: evidence.VoteA.Height}}, nil
+	case EvidenceTypeLightClientAttack:
+		var evidence LightClientAttackEvidence
+		if err := json.Unmarshal(e.Value, &evidence); err != nil {
+			return nil, fmt.Errorf("failed to parse light client attack evidence: %w", err)
+		}
+		offenders := make([]offender, 0, len(evidence.ByzantineValidators))
+		for _, validator := range evidence.ByzantineValidators {
+			offenders = append(offenders, offender{address: validator.Address, height: evidence.CommonHeight})
+		}
+		return offenders, nil
+	}
+	return nil, nil
+}
+
+// watchedValidators maps the consensus addresses of the target validator and
+// the configured validators to their labels.
+func watchedValidators(cfg *config.Config) map[string]string {
+	watched := map[string]string{}
+	if cfg.TargetValidator != "" {
+		watched[strings.ToUpper(cfg.TargetValidator)] = cfg.TargetValidator
+	}
+	for _, v := range cfg.Validators {
+		if v.ConsensusAddress != "" {
+			watched[strings.ToUpper(strings.TrimPrefix(v.ConsensusAddress, "0x"))] = v.Label()
+		}
+	}
+	return watched
+}
+
+// trackEvidence counts the evidence committed in block and raises the alarm
+// when it accuses one of our validators.
+func (p *BlockProcessor) trackEvidence(block *BlockResponse) {
+	evidence := block.Result.Block.Evidence.Evidence
+	if len(evidence) == 0 {
+		return
+	}
+	height := block.Result.Block.Header.Height
+	watched := watchedValidators(p.config)
+
+	for _, e := range evidence {
+		kind := evidenceKind(e.Type)
+		p.metrics.Evidence.WithLabelValues(kind).Inc()
+
+		offenders, err := e.offenders()
+		if err != nil {
+			p.metrics.Errors.Inc()
+			p.logger.WriteJSONLog(logger.LevelError, "Failed to parse evidence", map[string]interface{}{
+				"height": height,
+				"type":   e.Type,
+			}, err)
+			continue
+		}
+
+		addresses := make([]string, 0, len(offenders))
+		for _, o := range offenders {
+			addresses = append(addresses, o.address)
+		}
+		p.logger.WriteJSONLog(logger.LevelWarn, "Evidence committed", map[string]interface{}{
+			"height":    height,
+			"type":      kind,
+			"offenders": addresses,
+		}, nil)
+
+		for _, o := range offenders {
+			label, ok := watched[strings.ToUpper(o.address)]
+			if !ok {
+				continue
+			}
+			p.metrics.ValidatorEvidence.WithLabelValues(label, kind).Inc()
+			p.logger.WriteJSONLog(logger.LevelFatal, "Evidence against our validator", map[string]interface{}{
+				"height":            height,
+				"infraction_height": o.height,
+				"type":              kind,
+				"validator":         label,
+				"address":           o.address,
+			}, nil)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/metrics"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestTrackEvidence(t *testing.T) {
+	metrics := metrics.NewBlockMetrics()
+	processor, err := NewBlockProcessor(&config.Config{
+		TargetValidator: "AAAA",
+		ETHEndpoint:     "http://localhost:8545",
+		Validators:      []config.ValidatorConfig{{Name: "backup", ConsensusAddress: "0xbbbb"}},
+	}, metrics, newTestLogger())
+	if err != nil {
+		t.Fatalf("Failed to create processor: %v", err)
+	}
+
+	// Every watched validator is exported at zero before any evidence
+	if got := testutil.CollectAndCount(metrics.ValidatorEvidence); got != 4 {
+		t.Errorf("Expected 4 pre-registered evidence series, got %d", got)
+	}
+	if got := testutil.ToFloat64(metrics.ValidatorEvidence.WithLabelValues("backup", "light_client_attack")); got != 0 {
+		t.Errorf("Expected no evidence against backup yet, got %v", got)
+	}
+
+	var block BlockResponse
+	err = json.Unmarshal([]byte(`{"result":{"block":{"header":{"height":"500"},"evidence":{"evidence":[
+		{"type":"tendermint/DuplicateVoteEvidence","value":{
+			"vote_a":{"height":"498","round":0,"validator_address":"AAAA"},
+			"vote_b":{"height":"498","round":0,"validator_address":"AAAA"}}},
+		{"type":"tendermint/DuplicateVoteEvidence","value":{
+			"vote_a":{"height":"497","round":1,"validator_address":"CCCC"},
+			"vote_b":{"height":"497","round":1,"validator_address":"CCCC"}}},
+		{"type":"tendermint/LightClientAttackEvidence","value":{
+			"CommonHeight":"490","ByzantineValidators":[{"address":"BBBB"},{"address":"DDDD"}]}},
+		{"type":"tendermint/DuplicateVoteEvidence","value":"garbage"}
+	]}}}}`), &block)
+	if err != nil {
+		t.Fatalf("Failed to parse block: %v", err)
+	}
+
+	processor.trackEvidence(&block)
+
+	checks := map[string]struct {
+		got  float64
+		want float64
+	}{
+		"network duplicate votes":  {testutil.ToFloat64(metrics.Evidence.WithLabelValues("duplicate_vote")), 3},
+		"network attacks":          {testutil.ToFloat64(metrics.Evidence.WithLabelValues("light_client_attack")), 1},
+		"target double sign":       {testutil.ToFloat64(metrics.ValidatorEvidence.WithLabelValues("AAAA", "duplicate_vote")), 1},
+		"configured attack":        {testutil.ToFloat64(metrics.ValidatorEvidence.WithLabelValues("backup", "light_client_attack")), 1},
+		"unparseable evidence":     {testutil.ToFloat64(metrics.Errors), 1},
+		"other validators ignored": {float64(testutil.CollectAndCount(metrics.ValidatorEvidence)), 4},
+	}
+	for name, check := range checks {
+		if check.got != check.want {
+			t.Errorf("%s: expected %v, got %v", name, check.want, check.got)
+		}
+	}
+}
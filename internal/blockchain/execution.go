@@ -0,0 +1,136 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/logger"
+	"cosmos-evm-exporter/internal/metrics"
+	"cosmos-evm-exporter/internal/rpc"
+
+	"github.com/ethereum/go-ethereum"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// methodNotFound is the JSON-RPC error code for a method the client does not
+// expose.
+const methodNotFound = -32601
+
+// ExecutionHealthClient is the part of the execution client used by the
+// health probes.
+type ExecutionHealthClient interface {
+	SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error)
+	PeerCount(ctx context.Context) (uint64, error)
+	ClientVersion(ctx context.Context) (string, error)
+	ChainID(ctx context.Context) (*big.Int, error)
+	TxPoolStatus(ctx context.Context) (rpc.TxPoolStatus, error)
+}
+
+// ExecutionMonitor exports the health of the execution client, so a syncing
+// or misconfigured node shows up before it causes missed blocks.
+type ExecutionMonitor struct {
+	config  *config.Config
+	metrics *metrics.ExecutionNodeMetrics
+	logger  *logger.Logger
+	client  ExecutionHealthClient
+	// probeTxPool is off with disable_txpool_probe and turns off once the
+	// client reports txpool_status as unknown.
+	probeTxPool bool
+}
+
+func NewExecutionMonitor(config *config.Config, metrics *metrics.ExecutionNodeMetrics, logger *logger.Logger) (*ExecutionMonitor, error) {
+	client, err := rpc.NewClient(config.ETHEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExecutionMonitor{
+		config:      config,
+		metrics:     metrics,
+		logger:      logger.Component("execution"),
+		client:      client,
+		probeTxPool: !config.DisableTxPoolProbe,
+	}, nil
+}
+
+// Start probes the execution client every interval until ctx is done.
+func (m *ExecutionMonitor) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				m.probe(ctx)
+				time.Sleep(interval)
+			}
+		}
+	}()
+}
+
+// probe runs every health check once. Checks are independent, so one failing
+// method does not hide the others.
+func (m *ExecutionMonitor) probe(ctx context.Context) {
+	if progress, err := m.client.SyncProgress(ctx); err != nil {
+		m.probeFailed("eth_syncing", err)
+	} else if progress == nil {
+		m.metrics.Syncing.Set(0)
+		m.metrics.BlocksBehind.Set(0)
+	} else {
+		m.metrics.Syncing.Set(1)
+		m.metrics.BlocksBehind.Set(float64(progress.HighestBlock) - float64(progress.CurrentBlock))
+	}
+
+	if peers, err := m.client.PeerCount(ctx); err != nil {
+		m.probeFailed("net_peerCount", err)
+	} else {
+		m.metrics.Peers.Set(float64(peers))
+	}
+
+	if version, err := m.client.ClientVersion(ctx); err != nil {
+		m.probeFailed("web3_clientVersion", err)
+	} else {
+		m.metrics.Info.Reset()
+		m.metrics.Info.WithLabelValues(version).Set(1)
+	}
+
+	if chainID, err := m.client.ChainID(ctx); err != nil {
+		m.probeFailed("eth_chainId", err)
+	} else {
+		m.metrics.ChainID.Set(float64(chainID.Int64()))
+		mismatch := 0.0
+		if m.config.ExpectedChainID != 0 && chainID.Int64() != m.config.ExpectedChainID {
+			mismatch = 1
+			m.logger.WriteJSONLog(logger.LevelError, "Execution client is on the wrong chain", map[string]interface{}{
+				"chain_id":          chainID.Int64(),
+				"expected_chain_id": m.config.ExpectedChainID,
+			}, nil)
+		}
+		m.metrics.ChainIDMismatch.Set(mismatch)
+	}
+
+	if !m.probeTxPool {
+		return
+	}
+	if status, err := m.client.TxPoolStatus(ctx); err != nil {
+		m.probeFailed("txpool_status", err)
+		var rpcErr gethrpc.Error
+		if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == methodNotFound {
+			m.probeTxPool = false
+			m.logger.WriteJSONLog(logger.LevelInfo, "Execution client does not expose txpool_status, no longer probing it", nil, nil)
+		}
+	} else {
+		m.metrics.TxPool.WithLabelValues("pending").Set(float64(status.Pending))
+		m.metrics.TxPool.WithLabelValues("queued").Set(float64(status.Queued))
+	}
+}
+
+func (m *ExecutionMonitor) probeFailed(method string, err error) {
+	m.metrics.ProbeErrors.WithLabelValues(method).Inc()
+	m.logger.WriteJSONLog(logger.LevelWarn, "Execution client probe failed", map[string]interface{}{
+		"method": method,
+	}, err)
+}
@@ -0,0 +1,145 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/metrics"
+	"cosmos-evm-exporter/internal/rpc"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// MockHealthClient implements ExecutionHealthClient for testing
+type MockHealthClient struct {
+	progress *ethereum.SyncProgress
+	chainID  int64
+	txPool   error
+	txCalls  int
+}
+
+func (m *MockHealthClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	return m.progress, nil
+}
+
+func (m *MockHealthClient) PeerCount(ctx context.Context) (uint64, error) {
+	return 25, nil
+}
+
+func (m *MockHealthClient) ClientVersion(ctx context.Context) (string, error) {
+	return "Geth/v1.14.11-stable/linux-amd64/go1.22.1", nil
+}
+
+func (m *MockHealthClient) ChainID(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(m.chainID), nil
+}
+
+func (m *MockHealthClient) TxPoolStatus(ctx context.Context) (rpc.TxPoolStatus, error) {
+	m.txCalls++
+	if m.txPool != nil {
+		return rpc.TxPoolStatus{}, m.txPool
+	}
+	return rpc.TxPoolStatus{Pending: 12, Queued: 3}, nil
+}
+
+func TestExecutionMonitorProbe(t *testing.T) {
+	tests := []struct {
+		name         string
+		client       *MockHealthClient
+		wantSyncing  float64
+		wantBehind   float64
+		wantMismatch float64
+		wantTxErrors float64
+	}{
+		{
+			name:   "healthy",
+			client: &MockHealthClient{chainID: 80084},
+		},
+		{
+			name:         "syncing on the wrong chain without txpool",
+			client:       &MockHealthClient{progress: &ethereum.SyncProgress{CurrentBlock: 900, HighestBlock: 1000}, chainID: 1, txPool: fmt.Errorf("method not found")},
+			wantSyncing:  1,
+			wantBehind:   100,
+			wantMismatch: 1,
+			wantTxErrors: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeMetrics := metrics.NewExecutionNodeMetrics(prometheus.NewRegistry())
+			monitor := &ExecutionMonitor{
+				config:      &config.Config{ExpectedChainID: 80084},
+				metrics:     nodeMetrics,
+				logger:      newTestLogger(),
+				client:      tt.client,
+				probeTxPool: true,
+			}
+
+			monitor.probe(context.Background())
+
+			checks := map[string]struct {
+				metric prometheus.Collector
+				want   float64
+			}{
+				"syncing":        {nodeMetrics.Syncing, tt.wantSyncing},
+				"blocks behind":  {nodeMetrics.BlocksBehind, tt.wantBehind},
+				"peers":          {nodeMetrics.Peers, 25},
+				"chain mismatch": {nodeMetrics.ChainIDMismatch, tt.wantMismatch},
+				"txpool errors":  {nodeMetrics.ProbeErrors.WithLabelValues("txpool_status"), tt.wantTxErrors},
+				"client version": {nodeMetrics.Info.WithLabelValues("Geth/v1.14.11-stable/linux-amd64/go1.22.1"), 1},
+			}
+			for name, check := range checks {
+				if got := testutil.ToFloat64(check.metric); got != check.want {
+					t.Errorf("%s: expected %v, got %v", name, check.want, got)
+				}
+			}
+		})
+	}
+}
+
+// rpcError is a JSON-RPC error as returned by the go-ethereum client.
+type rpcError struct{ code int }
+
+func (e rpcError) Error() string  { return "the method txpool_status does not exist/is not available" }
+func (e rpcError) ErrorCode() int { return e.code }
+
+func TestExecutionMonitorTxPoolProbe(t *testing.T) {
+	tests := []struct {
+		name      string
+		disabled  bool
+		err       error
+		wantCalls int
+	}{
+		{name: "supported", wantCalls: 3},
+		{name: "disabled", disabled: true, wantCalls: 0},
+		{name: "method not found", err: fmt.Errorf("txpool_status failed: %w", rpcError{code: methodNotFound}), wantCalls: 1},
+		{name: "transient error", err: rpcError{code: -32000}, wantCalls: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &MockHealthClient{chainID: 80084, txPool: tt.err}
+			monitor := &ExecutionMonitor{
+				config:      &config.Config{DisableTxPoolProbe: tt.disabled},
+				metrics:     metrics.NewExecutionNodeMetrics(prometheus.NewRegistry()),
+				logger:      newTestLogger(),
+				client:      client,
+				probeTxPool: !tt.disabled,
+			}
+
+			for i := 0; i < 3; i++ {
+				monitor.probe(context.Background())
+			}
+
+			if client.txCalls != tt.wantCalls {
+				t.Errorf("Expected %d txpool_status calls, got %d", tt.wantCalls, client.txCalls)
+			}
+		})
+	}
+}
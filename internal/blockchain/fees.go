@@ -0,0 +1,133 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"cosmos-evm-exporter/internal/logger"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ReceiptsClient is implemented by EL clients that can fetch block receipts.
+// Fee accounting is skipped for clients that don't.
+type ReceiptsClient interface {
+	BlockReceipts(ctx context.Context, number *big.Int) ([]*types.Receipt, error)
+}
+
+// BalanceClient is implemented by EL clients that can read account balances.
+// Balance tracking is skipped for clients that don't.
+type BalanceClient interface {
+	BalanceAt(ctx context.Context, account common.Address, number *big.Int) (*big.Int, error)
+}
+
+// PriorityFees returns the priority fees paid to the fee recipient of block:
+// the effective tip of every transaction times the gas it used.
+func PriorityFees(block *types.Block, receipts []*types.Receipt) (*big.Int, error) {
+	txs := block.Transactions()
+	if len(receipts) != len(txs) {
+		return nil, fmt.Errorf("receipt count mismatch: %d receipts for %d transactions", len(receipts), len(txs))
+	}
+
+	total := new(big.Int)
+	for i, tx := range txs {
+		tip, err := tx.EffectiveGasTip(block.BaseFee())
+		if err != nil {
+			// Transactions below the base fee can't be included, so a valid
+			// block never gets here
+			return nil, fmt.Errorf("transaction %s: %w", tx.Hash().Hex(), err)
+		}
+		total.Add(total, new(big.Int).Mul(tip, new(big.Int).SetUint64(receipts[i].GasUsed)))
+	}
+	return total, nil
+}
+
+// blockFees fetches the receipts of block and returns its priority fees, or
+// nil if the EL client can't provide receipts.
+func (p *BlockProcessor) blockFees(block *types.Block) (*big.Int, error) {
+	client, ok := p.client.(ReceiptsClient)
+	if !ok {
+		return nil, nil
+	}
+	if len(block.Transactions()) == 0 {
+		return new(big.Int), nil
+	}
+
+	receipts, err := client.BlockReceipts(context.Background(), block.Number())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch receipts: %w", err)
+	}
+	return PriorityFees(block, receipts)
+}
+
+// blockReward returns what the fee recipient earns from one of our blocks:
+// its priority fees, or the builder's payment when a builder is the coinbase
+// and keeps the fees. It is nil if the EL client can't provide receipts.
+func (p *BlockProcessor) blockReward(block *types.Block) (*big.Int, error) {
+	if block.Coinbase().Hex() != p.config.EVMAddress {
+		if _, payment, paid := builderPayment(block, common.HexToAddress(p.config.EVMAddress)); paid {
+			return payment, nil
+		}
+	}
+	return p.blockFees(block)
+}
+
+// trackRewards accounts the reward of one of our blocks and follows the fee
+// recipient balance. The balance before our block is compared with the
+// balance after our previous block, so any decrease in between is an outflow
+// from the fee recipient.
+func (p *BlockProcessor) trackRewards(block *types.Block, reward *big.Int) (*big.Int, error) {
+	if reward != nil {
+		value, _ := new(big.Float).SetInt(reward).Float64()
+		p.metrics.Rewards.Add(value)
+		p.metrics.BlockRewards.Observe(value)
+	}
+
+	client, ok := p.client.(BalanceClient)
+	if !ok {
+		return nil, nil
+	}
+
+	account := common.HexToAddress(p.config.EVMAddress)
+	number := block.Number()
+	ctx := context.Background()
+
+	before, err := client.BalanceAt(ctx, account, new(big.Int).Sub(number, big.NewInt(1)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch balance before block: %w", err)
+	}
+	after, err := client.BalanceAt(ctx, account, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch balance: %w", err)
+	}
+
+	// Between our previous block and this one
+	if p.lastBalance != nil && before.Cmp(p.lastBalance) < 0 {
+		p.recordOutflow(account, number, new(big.Int).Sub(p.lastBalance, before))
+	}
+	// Within this block, where the balance should grow by the reward
+	if reward != nil {
+		expected := new(big.Int).Add(before, reward)
+		if after.Cmp(expected) < 0 {
+			p.recordOutflow(account, number, new(big.Int).Sub(expected, after))
+		}
+	}
+
+	p.lastBalance = after
+	balance, _ := new(big.Float).SetInt(after).Float64()
+	p.metrics.FeeRecipientBalance.Set(balance)
+	return after, nil
+}
+
+func (p *BlockProcessor) recordOutflow(account common.Address, number, outflow *big.Int) {
+	value, _ := new(big.Float).SetInt(outflow).Float64()
+	p.metrics.FeeRecipientOutflows.Inc()
+	p.metrics.FeeRecipientOutflow.Add(value)
+	p.logger.WriteJSONLog(logger.LevelWarn, "Fee recipient balance decreased", map[string]interface{}{
+		"address":     account.Hex(),
+		"el_height":   number.Int64(),
+		"outflow_wei": outflow.String(),
+	}, nil)
+}
@@ -0,0 +1,182 @@
+package blockchain
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/metrics"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestPriorityFees(t *testing.T) {
+	gwei := big.NewInt(1_000_000_000)
+	baseFee := new(big.Int).Mul(big.NewInt(10), gwei)
+
+	txs := []*types.Transaction{
+		// Tip capped at 2 gwei
+		types.NewTx(&types.DynamicFeeTx{
+			GasTipCap: new(big.Int).Mul(big.NewInt(2), gwei),
+			GasFeeCap: new(big.Int).Mul(big.NewInt(100), gwei),
+			Gas:       50000,
+		}),
+		// Legacy tip is the gas price above the base fee: 1 gwei
+		types.NewTx(&types.LegacyTx{
+			GasPrice: new(big.Int).Mul(big.NewInt(11), gwei),
+			Gas:      21000,
+		}),
+	}
+	receipts := []*types.Receipt{{GasUsed: 30000}, {GasUsed: 21000}}
+
+	header := &types.Header{Number: big.NewInt(100), BaseFee: baseFee}
+	block := types.NewBlock(header, &types.Body{Transactions: txs}, nil, trie.NewStackTrie(nil))
+
+	fees, err := PriorityFees(block, receipts)
+	if err != nil {
+		t.Fatalf("PriorityFees failed: %v", err)
+	}
+
+	// 30000 * 2 gwei + 21000 * 1 gwei
+	want := new(big.Int).Mul(big.NewInt(81000), gwei)
+	if fees.Cmp(want) != 0 {
+		t.Errorf("Expected fees %s, got %s", want, fees)
+	}
+
+	if _, err := PriorityFees(block, receipts[:1]); err == nil {
+		t.Error("Expected error for receipt count mismatch")
+	}
+}
+
+// MockBalanceClient adds BalanceAt to MockEthClient
+type MockBalanceClient struct {
+	MockEthClient
+	balances map[int64]*big.Int
+}
+
+func (m *MockBalanceClient) BalanceAt(ctx context.Context, account common.Address, number *big.Int) (*big.Int, error) {
+	return m.balances[number.Int64()], nil
+}
+
+func TestTrackRewards(t *testing.T) {
+	blockMetrics := metrics.NewBlockMetrics()
+	processor, err := NewBlockProcessor(&config.Config{
+		EVMAddress:  "0xabc",
+		ETHEndpoint: "http://localhost:8545",
+	}, blockMetrics, newTestLogger())
+	if err != nil {
+		t.Fatalf("Failed to create processor: %v", err)
+	}
+	processor.client = &MockBalanceClient{balances: map[int64]*big.Int{
+		99:  big.NewInt(1000),
+		100: big.NewInt(1500), // +500 fees
+		199: big.NewInt(1200), // 300 swept out since block 100
+		200: big.NewInt(1300), // +100 of 150 fees, 50 spent in the block
+	}}
+
+	block := func(number int64) *types.Block {
+		return types.NewBlockWithHeader(&types.Header{Number: big.NewInt(number)})
+	}
+
+	if balance, err := processor.trackRewards(block(100), big.NewInt(500)); err != nil || balance.Int64() != 1500 {
+		t.Fatalf("trackRewards() = %v, %v", balance, err)
+	}
+	if _, err := processor.trackRewards(block(200), big.NewInt(150)); err != nil {
+		t.Fatalf("trackRewards() error = %v", err)
+	}
+
+	checks := map[string]struct {
+		metric prometheus.Collector
+		want   float64
+	}{
+		"rewards":       {blockMetrics.Rewards, 650},
+		"balance":       {blockMetrics.FeeRecipientBalance, 1300},
+		"outflows":      {blockMetrics.FeeRecipientOutflows, 2},
+		"outflow total": {blockMetrics.FeeRecipientOutflow, 350},
+	}
+	for name, check := range checks {
+		if got := testutil.ToFloat64(check.metric); got != check.want {
+			t.Errorf("%s: expected %v, got %v", name, check.want, got)
+		}
+	}
+}
+
+// MockReceiptsClient adds BlockReceipts to MockEthClient
+type MockReceiptsClient struct {
+	MockEthClient
+	gasUsed uint64 // Gas used by every transaction
+}
+
+func (m *MockReceiptsClient) BlockReceipts(ctx context.Context, number *big.Int) ([]*types.Receipt, error) {
+	block, err := m.BlockByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	receipts := make([]*types.Receipt, len(block.Transactions()))
+	for i := range receipts {
+		receipts[i] = &types.Receipt{GasUsed: m.gasUsed}
+	}
+	return receipts, nil
+}
+
+func TestBlockReward(t *testing.T) {
+	ours := common.HexToAddress("0xabc")
+	builderKey, _ := crypto.GenerateKey()
+	builderAddress := crypto.PubkeyToAddress(builderKey.PublicKey)
+
+	// The builder pays 5e15 wei with a 2 wei tip, so the block's fees are 42000
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	payment, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		To:        &ours,
+		Value:     big.NewInt(5e15),
+		GasTipCap: big.NewInt(2),
+		GasFeeCap: big.NewInt(2),
+		Gas:       21000,
+	}), signer, builderKey)
+	if err != nil {
+		t.Fatalf("Failed to sign transaction: %v", err)
+	}
+	block := func(coinbase common.Address) *types.Block {
+		header := &types.Header{Number: big.NewInt(100), Coinbase: coinbase}
+		return types.NewBlock(header, &types.Body{Transactions: []*types.Transaction{payment}}, nil, trie.NewStackTrie(nil))
+	}
+
+	tests := []struct {
+		name     string
+		coinbase common.Address
+		want     *big.Int
+	}{
+		{"our coinbase earns the fees", ours, big.NewInt(42000)},
+		// The builder keeps the fees and pays us instead
+		{"builder coinbase earns the payment", builderAddress, big.NewInt(5e15)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, err := NewBlockProcessor(&config.Config{
+				EVMAddress:  ours.Hex(),
+				ETHEndpoint: "http://localhost:8545",
+			}, metrics.NewBlockMetrics(), newTestLogger())
+			if err != nil {
+				t.Fatalf("Failed to create processor: %v", err)
+			}
+			b := block(tt.coinbase)
+			processor.client = &MockReceiptsClient{
+				MockEthClient: MockEthClient{blocks: map[int64]*types.Block{100: b}},
+				gasUsed:       21000,
+			}
+
+			reward, err := processor.blockReward(b)
+			if err != nil || reward.Cmp(tt.want) != 0 {
+				t.Errorf("blockReward() = %v, %v, want %v", reward, err, tt.want)
+			}
+		})
+	}
+}
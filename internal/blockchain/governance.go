@@ -0,0 +1,200 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/lcd"
+	"cosmos-evm-exporter/internal/logger"
+	"cosmos-evm-exporter/internal/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const DefaultGovVoteAlertHours = 24
+
+// GovernanceClient is the part of the Cosmos REST API used by the governance
+// monitor.
+type GovernanceClient interface {
+	ActiveProposals(ctx context.Context) ([]lcd.Proposal, error)
+	Vote(ctx context.Context, id, voter string) (lcd.Vote, bool, error)
+}
+
+// voter is a configured validator and the account it votes with.
+type voter struct {
+	label   string
+	address string
+}
+
+// GovernanceMonitor checks that the configured validators vote on every
+// proposal before its voting period ends.
+type GovernanceMonitor struct {
+	metrics    *metrics.CosmosMetrics
+	logger     *logger.Logger
+	client     GovernanceClient
+	voters     []voter
+	alertAfter time.Duration
+	active     map[string]bool
+	overdue    map[string]bool
+	voted      map[string]bool
+}
+
+func NewGovernanceMonitor(config *config.Config, metrics *metrics.CosmosMetrics, logger *logger.Logger) (*GovernanceMonitor, error) {
+	voters, err := governanceVoters(config.Validators)
+	if err != nil {
+		return nil, err
+	}
+
+	alertHours := config.GovVoteAlertHours
+	if alertHours <= 0 {
+		alertHours = DefaultGovVoteAlertHours
+	}
+
+	return &GovernanceMonitor{
+		metrics:    metrics,
+		logger:     logger.Component("governance"),
+		client:     lcd.NewClient(config.LCDEndpoint),
+		voters:     voters,
+		alertAfter: time.Duration(alertHours) * time.Hour,
+		active:     map[string]bool{},
+		overdue:    map[string]bool{},
+		voted:      map[string]bool{},
+	}, nil
+}
+
+// governanceVoters returns the voting account of every validator that has
+// one configured or an operator address to derive it from.
+func governanceVoters(validators []config.ValidatorConfig) ([]voter, error) {
+	var voters []voter
+	for _, v := range validators {
+		address := v.AccountAddress
+		if address == "" && v.OperatorAddress != "" {
+			var err error
+			address, err = lcd.AccountAddress(v.OperatorAddress)
+			if err != nil {
+				return nil, fmt.Errorf("validator %s: %w", v.Label(), err)
+			}
+		}
+		if address != "" {
+			voters = append(voters, voter{label: v.Label(), address: address})
+		}
+	}
+	return voters, nil
+}
+
+// Start checks the active proposals every interval until ctx is done.
+func (m *GovernanceMonitor) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				if err := m.update(ctx, time.Now()); err != nil {
+					m.metrics.Errors.WithLabelValues("proposals").Inc()
+					m.logger.WriteJSONLog(logger.LevelError, "Failed to update governance proposals", nil, err)
+				}
+				time.Sleep(interval)
+			}
+		}
+	}()
+}
+
+func (m *GovernanceMonitor) update(ctx context.Context, now time.Time) error {
+	proposals, err := m.client.ActiveProposals(ctx)
+	if err != nil {
+		return err
+	}
+	m.metrics.ActiveProposals.Set(float64(len(proposals)))
+
+	current := map[string]bool{}
+	for _, proposal := range proposals {
+		current[proposal.ID] = true
+		if !m.active[proposal.ID] {
+			m.logger.WriteJSONLog(logger.LevelInfo, "Governance proposal in voting period", map[string]interface{}{
+				"proposal":        proposal.ID,
+				"title":           proposal.Title,
+				"voting_end_time": proposal.VotingEndTime,
+			}, nil)
+		}
+
+		left := proposal.VotingEndTime.Sub(now)
+		if left < 0 {
+			left = 0
+		}
+		m.metrics.VotingTimeLeft.WithLabelValues(proposal.ID).Set(left.Seconds())
+
+		for _, v := range m.voters {
+			m.updateVote(ctx, proposal, v, left)
+		}
+	}
+
+	// Drop the series of proposals that left their voting period
+	for id := range m.active {
+		if current[id] {
+			continue
+		}
+		labels := prometheus.Labels{"proposal": id}
+		m.metrics.VotingTimeLeft.DeletePartialMatch(labels)
+		m.metrics.Voted.DeletePartialMatch(labels)
+		m.metrics.VoteOverdue.DeletePartialMatch(labels)
+		for _, v := range m.voters {
+			delete(m.overdue, id+"/"+v.label)
+			delete(m.voted, id+"/"+v.label)
+		}
+	}
+	m.active = current
+
+	return nil
+}
+
+// updateVote exports whether v voted on proposal, logging once when the vote
+// becomes overdue or is cast.
+func (m *GovernanceMonitor) updateVote(ctx context.Context, proposal lcd.Proposal, v voter, left time.Duration) {
+	vote, voted, err := m.client.Vote(ctx, proposal.ID, v.address)
+	if err != nil {
+		m.metrics.Errors.WithLabelValues("vote").Inc()
+		m.logger.WriteJSONLog(logger.LevelError, "Failed to fetch governance vote", map[string]interface{}{
+			"proposal":  proposal.ID,
+			"validator": v.label,
+		}, err)
+		return
+	}
+
+	votedValue, overdueValue := 0.0, 0.0
+	if voted {
+		votedValue = 1
+	}
+	overdue := !voted && left < m.alertAfter
+	if overdue {
+		overdueValue = 1
+	}
+	m.metrics.Voted.WithLabelValues(proposal.ID, v.label).Set(votedValue)
+	m.metrics.VoteOverdue.WithLabelValues(proposal.ID, v.label).Set(overdueValue)
+
+	key := proposal.ID + "/" + v.label
+	if overdue && !m.overdue[key] {
+		m.logger.WriteJSONLog(logger.LevelWarn, "Governance vote overdue", map[string]interface{}{
+			"proposal":        proposal.ID,
+			"title":           proposal.Title,
+			"validator":       v.label,
+			"voter":           v.address,
+			"voting_end_time": proposal.VotingEndTime,
+		}, nil)
+	}
+	if voted && !m.voted[key] {
+		var options []string
+		for _, option := range vote.Options {
+			options = append(options, option.Option)
+		}
+		m.logger.WriteJSONLog(logger.LevelInfo, "Validator voted", map[string]interface{}{
+			"proposal":  proposal.ID,
+			"validator": v.label,
+			"options":   options,
+		}, nil)
+	}
+	m.overdue[key] = overdue
+	m.voted[key] = voted
+}
@@ -0,0 +1,115 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/lcd"
+	"cosmos-evm-exporter/internal/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// MockGovernanceClient implements GovernanceClient for testing
+type MockGovernanceClient struct {
+	proposals []lcd.Proposal
+	votes     map[string]bool
+}
+
+func (m *MockGovernanceClient) ActiveProposals(ctx context.Context) ([]lcd.Proposal, error) {
+	return m.proposals, nil
+}
+
+func (m *MockGovernanceClient) Vote(ctx context.Context, id, voter string) (lcd.Vote, bool, error) {
+	if !m.votes[id+"/"+voter] {
+		return lcd.Vote{}, false, nil
+	}
+	return lcd.Vote{ProposalID: id, Voter: voter}, true, nil
+}
+
+func TestGovernanceMonitor(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	client := &MockGovernanceClient{
+		proposals: []lcd.Proposal{
+			{ID: "7", Title: "Upgrade", VotingEndTime: now.Add(6 * time.Hour)},
+			{ID: "8", Title: "Community pool spend", VotingEndTime: now.Add(72 * time.Hour)},
+		},
+		votes: map[string]bool{"7/evm1b": true},
+	}
+
+	cosmosMetrics := metrics.NewCosmosMetrics(prometheus.NewRegistry())
+	monitor := &GovernanceMonitor{
+		metrics:    cosmosMetrics,
+		logger:     newTestLogger(),
+		client:     client,
+		voters:     []voter{{label: "a", address: "evm1a"}, {label: "b", address: "evm1b"}},
+		alertAfter: 24 * time.Hour,
+		active:     map[string]bool{},
+		overdue:    map[string]bool{},
+		voted:      map[string]bool{},
+	}
+
+	if err := monitor.update(context.Background(), now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	checks := map[string]struct {
+		metric prometheus.Collector
+		want   float64
+	}{
+		"active":             {cosmosMetrics.ActiveProposals, 2},
+		"time left":          {cosmosMetrics.VotingTimeLeft.WithLabelValues("7"), 6 * 3600},
+		"a voted on 7":       {cosmosMetrics.Voted.WithLabelValues("7", "a"), 0},
+		"b voted on 7":       {cosmosMetrics.Voted.WithLabelValues("7", "b"), 1},
+		"a overdue on 7":     {cosmosMetrics.VoteOverdue.WithLabelValues("7", "a"), 1},
+		"b overdue on 7":     {cosmosMetrics.VoteOverdue.WithLabelValues("7", "b"), 0},
+		"a not yet due on 8": {cosmosMetrics.VoteOverdue.WithLabelValues("8", "a"), 0},
+	}
+	for name, check := range checks {
+		if got := testutil.ToFloat64(check.metric); got != check.want {
+			t.Errorf("%s: expected %v, got %v", name, check.want, got)
+		}
+	}
+
+	// Proposal 7 ends, its series go away
+	client.proposals = client.proposals[1:]
+	if err := monitor.update(context.Background(), now.Add(7*time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := testutil.CollectAndCount(cosmosMetrics.Voted); got != 2 {
+		t.Errorf("expected only the votes on proposal 8 left, got %d series", got)
+	}
+	if got := testutil.CollectAndCount(cosmosMetrics.VotingTimeLeft); got != 1 {
+		t.Errorf("expected 1 proposal countdown, got %d", got)
+	}
+}
+
+func TestGovernanceVoters(t *testing.T) {
+	valoper, _ := lcd.EncodeBech32("evmvaloper", make([]byte, 20))
+	account, _ := lcd.EncodeBech32("evm", make([]byte, 20))
+
+	voters, err := governanceVoters([]config.ValidatorConfig{
+		{Name: "derived", OperatorAddress: valoper},
+		{Name: "explicit", OperatorAddress: valoper, AccountAddress: "evm1explicit"},
+		{Name: "consensus only", ConsensusAddress: "ABCD"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(voters) != 2 {
+		t.Fatalf("expected 2 voters, got %d", len(voters))
+	}
+	if voters[0].address != account {
+		t.Errorf("expected derived account %s, got %s", account, voters[0].address)
+	}
+	if voters[1].address != "evm1explicit" {
+		t.Errorf("expected explicit account, got %s", voters[1].address)
+	}
+
+	if _, err := governanceVoters([]config.ValidatorConfig{{OperatorAddress: "evmvaloper1bad"}}); err == nil {
+		t.Error("expected an error for an invalid operator address")
+	}
+}
@@ -0,0 +1,235 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	httpClient "cosmos-evm-exporter/internal/http"
+	"cosmos-evm-exporter/internal/logger"
+	"cosmos-evm-exporter/internal/proposals"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Verdicts of an inspection.
+const (
+	VerdictNotOurs    = "not_our_proposal"
+	VerdictConfirmed  = "confirmed"
+	VerdictMissed     = "missed"
+	VerdictUnresolved = "unresolved"
+)
+
+// Inspection is everything the exporter knows about one consensus height.
+type Inspection struct {
+	Height           int64             `json:"height"`
+	Hash             string            `json:"hash"`
+	Time             time.Time         `json:"time"`
+	Proposer         string            `json:"proposer"`
+	Ours             bool              `json:"ours"`
+	Txs              []InspectedTx     `json:"txs"`
+	Payload          *InspectedPayload `json:"payload,omitempty"`
+	Gap              int64             `json:"gap"`
+	ExpectedELHeight int64             `json:"expected_el_height"`
+	ScanStart        int64             `json:"scan_start"`
+	ScanEnd          int64             `json:"scan_end"`
+	ELBlock          *InspectedELBlock `json:"el_block,omitempty"`
+	Mismatches       []string          `json:"payload_mismatches,omitempty"`
+	Verdict          string            `json:"verdict"`
+	MissReason       string            `json:"miss_reason,omitempty"`
+}
+
+// InspectedTx is a decoded CL transaction. Value holds the typed payload.
+type InspectedTx struct {
+	Index   int         `json:"index"`
+	MsgType uint32      `json:"msg_type"`
+	Length  int         `json:"length"`
+	Type    string      `json:"type,omitempty"`
+	Value   interface{} `json:"value,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// InspectedPayload is the part of the execution payload carried by the
+// block that identifies the EL block it should produce.
+type InspectedPayload struct {
+	Number       uint64 `json:"number"`
+	BlockHash    string `json:"block_hash"`
+	ParentHash   string `json:"parent_hash"`
+	StateRoot    string `json:"state_root"`
+	FeeRecipient string `json:"fee_recipient"`
+	Timestamp    uint64 `json:"timestamp"`
+	GasUsed      uint64 `json:"gas_used"`
+	GasLimit     uint64 `json:"gas_limit"`
+	Txs          int    `json:"txs"`
+}
+
+// InspectedELBlock is the header of the EL block matched to the height, or
+// the block at the expected EL height when nothing matched.
+type InspectedELBlock struct {
+	Number     int64     `json:"number"`
+	Hash       string    `json:"hash"`
+	ParentHash string    `json:"parent_hash"`
+	Coinbase   string    `json:"coinbase"`
+	Time       time.Time `json:"time"`
+	GasUsed    uint64    `json:"gas_used"`
+	GasLimit   uint64    `json:"gas_limit"`
+	BaseFee    string    `json:"base_fee,omitempty"`
+	Txs        int       `json:"txs"`
+	Matched    bool      `json:"matched"`
+	Builder    string    `json:"builder,omitempty"`
+	PaymentWei string    `json:"builder_payment_wei,omitempty"`
+}
+
+// inspectOffset is how far around the expected EL height Inspect looks,
+// the same window checkExecutionBlocks starts from.
+const inspectOffset = 2
+
+// Inspect fetches the consensus block at height, decodes its transactions
+// and looks for its execution block the way the processor does. The EL block
+// checked is the one at the payload's number; the EL height is estimated from
+// the current CL/EL gap only for blocks without a payload.
+func (p *BlockProcessor) Inspect(height int64) (*Inspection, error) {
+	block, err := GetBlock(httpClient.NewClient(), p.config.RPCEndpoint, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %d: %w", height, err)
+	}
+	header := block.Result.Block.Header
+
+	inspection := &Inspection{
+		Height:   height,
+		Hash:     block.Result.BlockID.Hash,
+		Time:     header.Time,
+		Proposer: header.ProposerAddress,
+		Ours:     header.ProposerAddress == p.config.TargetValidator,
+	}
+
+	var payload *engine.ExecutableData
+	for i, txBase64 := range block.Result.Block.Data.Txs {
+		tx := p.inspectTx(i, txBase64)
+		if data, ok := tx.Value.(*engine.ExecutableData); ok && payload == nil {
+			payload = data
+			inspection.Payload = inspectPayload(data)
+		}
+		inspection.Txs = append(inspection.Txs, tx)
+	}
+
+	if payload != nil {
+		// The payload names its EL block, so only that height is checked
+		inspection.ExpectedELHeight = int64(payload.Number)
+		inspection.Gap = height - inspection.ExpectedELHeight
+		inspection.ScanStart = inspection.ExpectedELHeight
+		inspection.ScanEnd = inspection.ExpectedELHeight
+	} else {
+		gap, err := p.GetCurrentGap()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current gap: %w", err)
+		}
+		inspection.Gap = gap
+		inspection.ExpectedELHeight = height - gap
+		inspection.ScanStart = inspection.ExpectedELHeight - inspectOffset
+		inspection.ScanEnd = inspection.ExpectedELHeight + inspectOffset
+	}
+
+	scan := p.scanExecutionBlocks(height, inspection.ScanStart, inspection.ScanEnd)
+	_, produced := scan.coinbases[inspection.ExpectedELHeight]
+	if scan.block != nil {
+		inspection.ELBlock = inspectELBlock(scan.block, true)
+		attribution := attributeBlock(scan.block, common.HexToAddress(p.config.EVMAddress), p.builders)
+		inspection.ELBlock.Builder = attribution.Builder
+		if attribution.Payment != nil {
+			inspection.ELBlock.PaymentWei = attribution.Payment.String()
+		}
+		if payload != nil {
+			for field := range payloadMismatches(payload, scan.block) {
+				inspection.Mismatches = append(inspection.Mismatches, field)
+			}
+			sort.Strings(inspection.Mismatches)
+		}
+	} else if elBlock, err := p.client.BlockByNumber(context.Background(), big.NewInt(inspection.ExpectedELHeight)); err == nil {
+		inspection.ELBlock = inspectELBlock(elBlock, false)
+	}
+
+	switch {
+	case !inspection.Ours:
+		inspection.Verdict = VerdictNotOurs
+	case scan.block != nil:
+		inspection.Verdict = VerdictConfirmed
+	case payload != nil && !produced && scan.failed == 0:
+		// The EL has not reached the payload's block yet
+		inspection.Verdict = VerdictUnresolved
+	default:
+		record := proposals.Record{
+			CLHeight:       height,
+			ScanStart:      inspection.ScanStart,
+			ScanEnd:        inspection.ScanEnd,
+			EmptyConsensus: len(block.Result.Block.Data.Txs) == 0,
+		}
+		reason := p.classifyMiss(record, inspection.ExpectedELHeight, scan)
+		inspection.MissReason = string(reason)
+		inspection.Verdict = VerdictMissed
+		if reason == proposals.MissELUnreachable {
+			inspection.Verdict = VerdictUnresolved
+		}
+	}
+
+	p.logger.WriteJSONLog(logger.LevelDebug, "Inspected height", map[string]interface{}{
+		"height":  height,
+		"verdict": inspection.Verdict,
+	}, nil)
+	return inspection, nil
+}
+
+func (p *BlockProcessor) inspectTx(index int, txBase64 string) InspectedTx {
+	tx := InspectedTx{Index: index}
+
+	if frame, err := DecodeTx(txBase64, p.decoderLogger); err == nil {
+		tx.MsgType = frame.MsgType
+		tx.Length = int(frame.DataLength)
+	}
+
+	decoded, err := DecodeCLTx(txBase64)
+	if err != nil {
+		tx.Error = err.Error()
+		return tx
+	}
+	tx.MsgType = decoded.MsgType
+	tx.Type = decoded.Type
+	tx.Value = decoded.Value
+	return tx
+}
+
+func inspectPayload(data *engine.ExecutableData) *InspectedPayload {
+	return &InspectedPayload{
+		Number:       data.Number,
+		BlockHash:    data.BlockHash.Hex(),
+		ParentHash:   data.ParentHash.Hex(),
+		StateRoot:    data.StateRoot.Hex(),
+		FeeRecipient: data.FeeRecipient.Hex(),
+		Timestamp:    data.Timestamp,
+		GasUsed:      data.GasUsed,
+		GasLimit:     data.GasLimit,
+		Txs:          len(data.Transactions),
+	}
+}
+
+func inspectELBlock(block *types.Block, matched bool) *InspectedELBlock {
+	elBlock := &InspectedELBlock{
+		Number:     block.Number().Int64(),
+		Hash:       block.Hash().Hex(),
+		ParentHash: block.ParentHash().Hex(),
+		Coinbase:   block.Coinbase().Hex(),
+		Time:       time.Unix(int64(block.Time()), 0).UTC(),
+		GasUsed:    block.GasUsed(),
+		GasLimit:   block.GasLimit(),
+		Txs:        len(block.Transactions()),
+		Matched:    matched,
+	}
+	if baseFee := block.BaseFee(); baseFee != nil {
+		elBlock.BaseFee = baseFee.String()
+	}
+	return elBlock
+}
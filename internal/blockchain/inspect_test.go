@@ -0,0 +1,151 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/metrics"
+	"cosmos-evm-exporter/internal/proposals"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestInspect(t *testing.T) {
+	ours := common.HexToAddress("0xabc")
+	other := common.HexToAddress("0xdef")
+
+	payload, err := json.Marshal(engine.ExecutableData{
+		ParentHash:    common.HexToHash("0x01"),
+		FeeRecipient:  ours,
+		StateRoot:     common.HexToHash("0x02"),
+		BlockHash:     common.HexToHash("0x03"),
+		Number:        101,
+		GasLimit:      30000000,
+		GasUsed:       21000,
+		Timestamp:     1700000000,
+		BaseFeePerGas: big.NewInt(7),
+		LogsBloom:     make([]byte, 256),
+		ExtraData:     []byte{},
+		Transactions:  [][]byte{{0x01}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode payload: %v", err)
+	}
+	frame := make([]byte, 8+len(payload))
+	binary.BigEndian.PutUint32(frame[0:4], MsgTypeExecutionPayload)
+	binary.BigEndian.PutUint32(frame[4:8], uint32(len(payload)))
+	copy(frame[8:], payload)
+	payloadTx := base64.StdEncoding.EncodeToString(frame)
+	unknownTx := base64.StdEncoding.EncodeToString([]byte{0, 0, 0, 99, 0, 0, 0, 0})
+
+	tests := []struct {
+		name        string
+		proposer    string
+		noPayload   bool
+		head        int64
+		ourBlockAt  int64
+		wantEL      int64
+		wantVerdict string
+		wantReason  proposals.MissReason
+		wantMatched bool
+	}{
+		{name: "confirmed", proposer: "validator1", ourBlockAt: 101, wantEL: 101, wantVerdict: VerdictConfirmed, wantMatched: true},
+		{name: "missed", proposer: "validator1", wantEL: 101, wantVerdict: VerdictMissed, wantReason: proposals.MissOtherProposer},
+		{name: "not ours", proposer: "validator2", wantEL: 101, wantVerdict: VerdictNotOurs},
+		// The payload's block is past the EL head
+		{name: "not produced yet", proposer: "validator1", head: 100, wantEL: 101, wantVerdict: VerdictUnresolved},
+		// Without a payload, the gap of 50 gives the EL height
+		{name: "no payload", proposer: "validator1", noPayload: true, ourBlockAt: 100, wantEL: 100, wantVerdict: VerdictConfirmed, wantMatched: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txs := []string{payloadTx, unknownTx}
+			if tt.noPayload {
+				txs = []string{unknownTx}
+			}
+			block := &BlockResponse{Result: BlockResult{
+				BlockID: BlockID{Hash: "hash_150"},
+				Block: Block{
+					Header: BlockHeader{Height: "150", ProposerAddress: tt.proposer, Time: time.Now()},
+					Data:   BlockData{Txs: txs},
+				},
+			}}
+			clServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/status":
+					json.NewEncoder(w).Encode(StatusResponse{Result: StatusResult{SyncInfo: SyncInfo{LatestBlockHeight: "150"}}})
+				case "/block":
+					json.NewEncoder(w).Encode(block)
+				}
+			}))
+			defer clServer.Close()
+			elServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintln(w, `{"jsonrpc":"2.0","id":1,"result":"0x64"}`) // Gap of 50
+			}))
+			defer elServer.Close()
+
+			processor, err := NewBlockProcessor(&config.Config{
+				TargetValidator: "validator1",
+				EVMAddress:      ours.Hex(),
+				ETHEndpoint:     elServer.URL,
+				RPCEndpoint:     clServer.URL,
+			}, metrics.NewBlockMetrics(), newTestLogger())
+			if err != nil {
+				t.Fatalf("Failed to create processor: %v", err)
+			}
+			client := &MockEthClient{blocks: make(map[int64]*types.Block), head: tt.head}
+			for height := int64(80); height <= 120; height++ {
+				coinbase := other
+				if height == tt.ourBlockAt {
+					coinbase = ours
+				}
+				client.blocks[height] = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(height), Coinbase: coinbase})
+			}
+			processor.client = client
+
+			inspection, err := processor.Inspect(150)
+			if err != nil {
+				t.Fatalf("Inspect() error = %v", err)
+			}
+
+			if inspection.ExpectedELHeight != tt.wantEL || inspection.Verdict != tt.wantVerdict || inspection.MissReason != string(tt.wantReason) {
+				t.Errorf("Expected EL height %d, verdict %s and reason %q, got %+v", tt.wantEL, tt.wantVerdict, tt.wantReason, inspection)
+			}
+			if tt.noPayload {
+				if inspection.Payload != nil || len(inspection.Txs) != 1 || inspection.Txs[0].Error == "" {
+					t.Errorf("Expected an unknown tx and no payload, got %+v", inspection.Txs)
+				}
+			} else {
+				if len(inspection.Txs) != 2 || inspection.Txs[0].Type != "execution_payload" || inspection.Txs[1].Error == "" {
+					t.Errorf("Expected a decoded payload and an unknown tx, got %+v", inspection.Txs)
+				}
+				if inspection.Payload == nil || inspection.Payload.Number != 101 || inspection.Payload.FeeRecipient != ours.Hex() {
+					t.Errorf("Expected payload fields of block 101, got %+v", inspection.Payload)
+				}
+			}
+			if tt.head > 0 {
+				if inspection.ELBlock != nil {
+					t.Errorf("Expected no EL block past the head, got %+v", inspection.ELBlock)
+				}
+				return
+			}
+			if inspection.ELBlock == nil || inspection.ELBlock.Matched != tt.wantMatched || inspection.ELBlock.Number != tt.wantEL {
+				t.Fatalf("Expected EL block %d with matched %t, got %+v", tt.wantEL, tt.wantMatched, inspection.ELBlock)
+			}
+			if tt.wantMatched && !tt.noPayload && len(inspection.Mismatches) == 0 {
+				t.Errorf("Expected the payload block hash to disagree with the EL block")
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"cosmos-evm-exporter/internal/config"
+	httpClient "cosmos-evm-exporter/internal/http"
+	"cosmos-evm-exporter/internal/logger"
+	"cosmos-evm-exporter/internal/metrics"
+)
+
+// NodeMonitor exports the health of the consensus node from /status and
+// /net_info.
+type NodeMonitor struct {
+	config  *config.Config
+	metrics *metrics.NodeMetrics
+	logger  *logger.Logger
+	client  *httpClient.Client
+}
+
+func NewNodeMonitor(config *config.Config, metrics *metrics.NodeMetrics, logger *logger.Logger) *NodeMonitor {
+	return &NodeMonitor{
+		config:  config,
+		metrics: metrics,
+		logger:  logger.Component("node"),
+		client:  httpClient.NewClient(),
+	}
+}
+
+// Start polls the node every interval until ctx is done.
+func (m *NodeMonitor) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				if err := m.updateStatus(time.Now()); err != nil {
+					m.logger.WriteJSONLog(logger.LevelError, "Failed to update node status", nil, err)
+				}
+				if err := m.updatePeers(); err != nil {
+					m.logger.WriteJSONLog(logger.LevelError, "Failed to update node peers", nil, err)
+				}
+				time.Sleep(interval)
+			}
+		}
+	}()
+}
+
+func (m *NodeMonitor) updateStatus(now time.Time) error {
+	status, err := GetStatus(m.client, m.config.RPCEndpoint)
+	if err != nil {
+		return err
+	}
+	result := status.Result
+
+	catchingUp := 0.0
+	if result.SyncInfo.CatchingUp {
+		catchingUp = 1
+	}
+	m.metrics.CatchingUp.Set(catchingUp)
+
+	if !result.SyncInfo.LatestBlockTime.IsZero() {
+		m.metrics.LatestBlockAge.Set(now.Sub(result.SyncInfo.LatestBlockTime).Seconds())
+	}
+
+	// Drop the previous labels so an upgrade does not leave a stale series
+	m.metrics.Info.Reset()
+	m.metrics.Info.WithLabelValues(result.NodeInfo.Version, result.NodeInfo.Network, result.NodeInfo.Moniker).Set(1)
+
+	if result.ValidatorInfo.VotingPower != "" {
+		power, err := strconv.ParseFloat(result.ValidatorInfo.VotingPower, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse voting power: %w", err)
+		}
+		m.metrics.VotingPower.Set(power)
+	}
+
+	return nil
+}
+
+func (m *NodeMonitor) updatePeers() error {
+	netInfo, err := GetNetInfo(m.client, m.config.RPCEndpoint)
+	if err != nil {
+		return err
+	}
+
+	var inbound, outbound int
+	for _, peer := range netInfo.Result.Peers {
+		if peer.IsOutbound {
+			outbound++
+		} else {
+			inbound++
+		}
+	}
+	m.metrics.Peers.WithLabelValues("inbound").Set(float64(inbound))
+	m.metrics.Peers.WithLabelValues("outbound").Set(float64(outbound))
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package blockchain
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestNodeMonitor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/status":
+			w.Write([]byte(`{"jsonrpc":"2.0","id":-1,"result":{
+				"node_info":{"id":"abc","network":"bartio","version":"0.38.12","moniker":"sentry-1"},
+				"sync_info":{"latest_block_height":"7458296","latest_block_time":"2024-11-15T10:53:30Z","catching_up":true},
+				"validator_info":{"address":"B2A5C37E","voting_power":"250000"}}}`))
+		case "/net_info":
+			w.Write([]byte(`{"jsonrpc":"2.0","id":-1,"result":{"n_peers":"3","peers":[
+				{"is_outbound":true},{"is_outbound":false},{"is_outbound":true}]}}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	nodeMetrics := metrics.NewNodeMetrics(prometheus.NewRegistry())
+	monitor := NewNodeMonitor(&config.Config{RPCEndpoint: server.URL}, nodeMetrics, newTestLogger())
+
+	now := time.Date(2024, 11, 15, 10, 53, 40, 0, time.UTC)
+	if err := monitor.updateStatus(now); err != nil {
+		t.Fatalf("updateStatus() error = %v", err)
+	}
+	if err := monitor.updatePeers(); err != nil {
+		t.Fatalf("updatePeers() error = %v", err)
+	}
+
+	checks := map[string]struct {
+		metric prometheus.Collector
+		want   float64
+	}{
+		"catching up":   {nodeMetrics.CatchingUp, 1},
+		"block age":     {nodeMetrics.LatestBlockAge, 10},
+		"voting power":  {nodeMetrics.VotingPower, 250000},
+		"inbound peers": {nodeMetrics.Peers.WithLabelValues("inbound"), 1},
+		"outbound":      {nodeMetrics.Peers.WithLabelValues("outbound"), 2},
+	}
+	for name, check := range checks {
+		if got := testutil.ToFloat64(check.metric); got != check.want {
+			t.Errorf("%s: expected %v, got %v", name, check.want, got)
+		}
+	}
+
+	expected := `
+# HELP consensus_node_info Version and network of the consensus node, always 1
+# TYPE consensus_node_info gauge
+consensus_node_info{moniker="sentry-1",network="bartio",version="0.38.12"} 1
+`
+	if err := testutil.CollectAndCompare(nodeMetrics.Info, strings.NewReader(expected)); err != nil {
+		t.Errorf("CollectAndCompare() error = %v", err)
+	}
+}
@@ -70,30 +70,61 @@ func GetBlock(client *httpClient.Client, endpoint string, height int64) (*BlockR
 }
 
 func (p *BlockProcessor) GetCurrentHeight() (int64, error) {
-	req, err := http.NewRequest("GET", p.config.RPCEndpoint+"/status", nil)
+	status, err := GetStatus(httpClient.NewClient(), p.config.RPCEndpoint)
 	if err != nil {
-		return 0, fmt.Errorf("failed to create request: %w", err)
+		return 0, err
+	}
+
+	var height int64
+	_, err = fmt.Sscanf(status.Result.SyncInfo.LatestBlockHeight, "%d", &height)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse block height: %w", err)
+	}
+
+	return height, nil
+}
+
+// GetStatus fetches the node, sync and validator information of the
+// consensus node from /status.
+func GetStatus(client *httpClient.Client, endpoint string) (*StatusResponse, error) {
+	req, err := http.NewRequest("GET", endpoint+"/status", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	client := httpClient.NewClient()
 	resp, err := client.DoRequest(req)
 	if err != nil {
-		return 0, fmt.Errorf("failed to fetch status: %w", err)
+		return nil, fmt.Errorf("failed to fetch status: %w", err)
 	}
 	defer resp.Body.Close()
 
 	var status StatusResponse
 	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
-		return 0, fmt.Errorf("failed to parse status response: %w", err)
+		return nil, fmt.Errorf("failed to parse status response: %w", err)
 	}
 
-	var height int64
-	_, err = fmt.Sscanf(status.Result.SyncInfo.LatestBlockHeight, "%d", &height)
+	return &status, nil
+}
+
+// GetNetInfo fetches the peers of the consensus node from /net_info.
+func GetNetInfo(client *httpClient.Client, endpoint string) (*NetInfoResponse, error) {
+	req, err := http.NewRequest("GET", endpoint+"/net_info", nil)
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse block height: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	return height, nil
+	resp, err := client.DoRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch net info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var netInfo NetInfoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&netInfo); err != nil {
+		return nil, fmt.Errorf("failed to parse net info response: %w", err)
+	}
+
+	return &netInfo, nil
 }
 
 func (p *BlockProcessor) GetCurrentELHeight() (int64, error) {
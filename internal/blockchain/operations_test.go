@@ -21,14 +21,8 @@ func TestGetCurrentHeight(t *testing.T) {
 		w.Header().Set("Content-Type", "application/json")
 		if r.URL.Path == "/status" {
 			json.NewEncoder(w).Encode(StatusResponse{
-				Result: struct {
-					SyncInfo struct {
-						LatestBlockHeight string `json:"latest_block_height"`
-					} `json:"sync_info"`
-				}{
-					SyncInfo: struct {
-						LatestBlockHeight string `json:"latest_block_height"`
-					}{
+				Result: StatusResult{
+					SyncInfo: SyncInfo{
 						LatestBlockHeight: "7368349",
 					},
 				},
@@ -94,100 +88,9 @@ func TestGetBlock(t *testing.T) {
 			response := BlockResponse{
 				JsonRPC: "2.0",
 				ID:      1,
-				Result: struct {
-					BlockID struct {
-						Hash  string `json:"hash"`
-						Parts struct {
-							Total int    `json:"total"`
-							Hash  string `json:"hash"`
-						} `json:"parts"`
-					} `json:"block_id"`
-					Block struct {
-						Header struct {
-							Version struct {
-								Block string `json:"block"`
-								App   string `json:"app"`
-							} `json:"version"`
-							ChainID     string    `json:"chain_id"`
-							Height      string    `json:"height"`
-							Time        time.Time `json:"time"`
-							LastBlockID struct {
-								Hash  string `json:"hash"`
-								Parts struct {
-									Total int    `json:"total"`
-									Hash  string `json:"hash"`
-								} `json:"parts"`
-							} `json:"last_block_id"`
-							LastCommitHash     string `json:"last_commit_hash"`
-							DataHash           string `json:"data_hash"`
-							ValidatorsHash     string `json:"validators_hash"`
-							NextValidatorsHash string `json:"next_validators_hash"`
-							ConsensusHash      string `json:"consensus_hash"`
-							AppHash            string `json:"app_hash"`
-							LastResultsHash    string `json:"last_results_hash"`
-							EvidenceHash       string `json:"evidence_hash"`
-							ProposerAddress    string `json:"proposer_address"`
-						} `json:"header"`
-						Data struct {
-							Txs []string `json:"txs"`
-						} `json:"data"`
-					} `json:"block"`
-				}{
-					Block: struct {
-						Header struct {
-							Version struct {
-								Block string `json:"block"`
-								App   string `json:"app"`
-							} `json:"version"`
-							ChainID     string    `json:"chain_id"`
-							Height      string    `json:"height"`
-							Time        time.Time `json:"time"`
-							LastBlockID struct {
-								Hash  string `json:"hash"`
-								Parts struct {
-									Total int    `json:"total"`
-									Hash  string `json:"hash"`
-								} `json:"parts"`
-							} `json:"last_block_id"`
-							LastCommitHash     string `json:"last_commit_hash"`
-							DataHash           string `json:"data_hash"`
-							ValidatorsHash     string `json:"validators_hash"`
-							NextValidatorsHash string `json:"next_validators_hash"`
-							ConsensusHash      string `json:"consensus_hash"`
-							AppHash            string `json:"app_hash"`
-							LastResultsHash    string `json:"last_results_hash"`
-							EvidenceHash       string `json:"evidence_hash"`
-							ProposerAddress    string `json:"proposer_address"`
-						} `json:"header"`
-						Data struct {
-							Txs []string `json:"txs"`
-						} `json:"data"`
-					}{
-						Header: struct {
-							Version struct {
-								Block string `json:"block"`
-								App   string `json:"app"`
-							} `json:"version"`
-							ChainID     string    `json:"chain_id"`
-							Height      string    `json:"height"`
-							Time        time.Time `json:"time"`
-							LastBlockID struct {
-								Hash  string `json:"hash"`
-								Parts struct {
-									Total int    `json:"total"`
-									Hash  string `json:"hash"`
-								} `json:"parts"`
-							} `json:"last_block_id"`
-							LastCommitHash     string `json:"last_commit_hash"`
-							DataHash           string `json:"data_hash"`
-							ValidatorsHash     string `json:"validators_hash"`
-							NextValidatorsHash string `json:"next_validators_hash"`
-							ConsensusHash      string `json:"consensus_hash"`
-							AppHash            string `json:"app_hash"`
-							LastResultsHash    string `json:"last_results_hash"`
-							EvidenceHash       string `json:"evidence_hash"`
-							ProposerAddress    string `json:"proposer_address"`
-						}{
+				Result: BlockResult{
+					Block: Block{
+						Header: BlockHeader{
 							Height:          "1000",
 							ProposerAddress: "validProposer",
 							Time:            time.Now(),
@@ -2,6 +2,7 @@ package blockchain
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"strconv"
@@ -11,7 +12,11 @@ import (
 	httpClient "cosmos-evm-exporter/internal/http"
 	"cosmos-evm-exporter/internal/logger"
 	"cosmos-evm-exporter/internal/metrics"
+	"cosmos-evm-exporter/internal/proposals"
 	"cosmos-evm-exporter/internal/rpc"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 func NewBlockProcessor(config *config.Config, metrics *metrics.BlockMetrics, logger *logger.Logger) (*BlockProcessor, error) {
@@ -19,13 +24,46 @@ func NewBlockProcessor(config *config.Config, metrics *metrics.BlockMetrics, log
 	if err != nil {
 		return nil, fmt.Errorf("failed to create RPC client: %w", err)
 	}
+	builders, err := compileBuilders(config.Builders)
+	if err != nil {
+		return nil, err
+	}
+
+	// Export every miss reason from the start so rates can be computed
+	for _, reason := range proposals.MissReasons {
+		metrics.ExecutionMissed.WithLabelValues(string(reason))
+	}
+	for _, txType := range TxTypes() {
+		metrics.CLTxs.WithLabelValues(txType)
+	}
+	for _, field := range PayloadFields {
+		metrics.PayloadMismatches.WithLabelValues(field)
+	}
+	for _, label := range watchedValidators(config) {
+		for _, kind := range offenderKinds {
+			metrics.ValidatorEvidence.WithLabelValues(label, kind)
+		}
+	}
+	metrics.BuilderBlocks.WithLabelValues(config.TargetValidator, BuilderLocal)
+	metrics.BuilderBlocks.WithLabelValues(config.TargetValidator, BuilderUnknown)
+	for _, b := range builders {
+		metrics.BuilderBlocks.WithLabelValues(config.TargetValidator, b.name)
+	}
 
 	return &BlockProcessor{
-		config:  config,
-		logger:  logger,
-		metrics: metrics,
-		client:  client,
+		config:            config,
+		logger:            logger.Component("processor"),
+		rpcLogger:         logger.Component("rpc"),
+		decoderLogger:     logger.Component("decoder"),
+		consensusLogger:   logger.Component("consensus"),
+		metrics:           metrics,
+		client:            client,
 		lastFoundELHeight: 0,
+		blockTimes:        NewBlockTimes(),
+		elMatches:         map[int64]elMatch{},
+		unknownMsgTypes:   map[uint32]bool{},
+		headers:           newHeaderCache(),
+		builders:          builders,
 	}, nil
 }
 
@@ -37,7 +75,7 @@ func (p *BlockProcessor) ProcessBlock(block *BlockResponse) error {
 
 	header := block.Result.Block.Header
 
-	p.logger.WriteJSONLog("debug", "Processing block", map[string]interface{}{
+	p.logger.WriteJSONLog(logger.LevelDebug, "Processing block", map[string]interface{}{
 		"height":           header.Height,
 		"proposer_address": header.ProposerAddress,
 	}, nil)
@@ -47,6 +85,13 @@ func (p *BlockProcessor) ProcessBlock(block *BlockResponse) error {
 		return fmt.Errorf("received empty proposer address for height %s", header.Height)
 	}
 
+	if height, err := strconv.ParseInt(header.Height, 10, 64); err == nil {
+		p.blockTimes.Observe(height, header.Time)
+	}
+	p.trackSigning(block)
+	p.trackEvidence(block)
+	p.decodeTxs(block)
+
 	if header.ProposerAddress != p.config.TargetValidator {
 		return nil // Not our validator
 	}
@@ -58,21 +103,43 @@ func (p *BlockProcessor) ProcessBlock(block *BlockResponse) error {
 
 	p.metrics.CurrentHeight.Set(float64(clHeight))
 	p.metrics.TotalProposed.Inc()
-	p.logger.WriteJSONLog("info", "Found validator block", map[string]interface{}{
+	p.logger.WriteJSONLog(logger.LevelInfo, "Found validator block", map[string]interface{}{
 		"height":           clHeight,
 		"proposer_address": header.ProposerAddress,
 	}, nil)
 
-	gap, err := p.GetCurrentGap()
+	expectedELHeight, err := p.expectedELHeight(block, clHeight)
 	if err != nil {
-		return fmt.Errorf("failed to get current gap: %w", err)
+		p.recordProposal(proposals.Record{
+			Validator:  p.config.TargetValidator,
+			CLHeight:   clHeight,
+			BlockTime:  header.Time,
+			ObservedAt: time.Now().UTC(),
+		}.WithError(err))
+		return err
 	}
 
-	expectedELHeight := clHeight - gap
-	return p.checkExecutionBlocks(clHeight, expectedELHeight)
+	return p.checkExecutionBlocks(clHeight, header.Time, expectedELHeight)
+}
+
+// expectedELHeight returns the execution height of the proposal in block: the
+// number of the execution payload it carries, or an estimate from the current
+// CL/EL gap when it carries none.
+func (p *BlockProcessor) expectedELHeight(block *BlockResponse, clHeight int64) (int64, error) {
+	if payload := executionPayload(block); payload != nil {
+		return int64(payload.Number), nil
+	}
+
+	gap, err := p.GetCurrentGap()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get current gap: %w", err)
+	}
+	return clHeight - gap, nil
 }
 
-func (p *BlockProcessor) checkExecutionBlocks(clHeight, expectedELHeight int64) error {
+// checkExecutionBlocks looks for the execution block of our proposal at
+// clHeight, made at blockTime, and records the outcome.
+func (p *BlockProcessor) checkExecutionBlocks(clHeight int64, blockTime time.Time, expectedELHeight int64) error {
 	const defaultOffset = 2 // Default blocks to check before and after expected height
 
 	startHeight := expectedELHeight - defaultOffset
@@ -84,67 +151,361 @@ func (p *BlockProcessor) checkExecutionBlocks(clHeight, expectedELHeight int64)
 		endHeight = startHeight + (defaultOffset * 2)
 	}
 
+	record := proposals.Record{
+		Validator:  p.config.TargetValidator,
+		CLHeight:   clHeight,
+		ScanStart:  startHeight,
+		ScanEnd:    endHeight,
+		BlockTime:  blockTime,
+		ObservedAt: time.Now().UTC(),
+	}
+
 	// Check if consensus block was empty
 	block, err := GetBlock(httpClient.NewClient(), p.config.RPCEndpoint, clHeight)
 	if err != nil {
 		p.metrics.Errors.Inc()
-		return fmt.Errorf("failed to get consensus block: %w", err)
+		err = fmt.Errorf("failed to get consensus block: %w", err)
+		p.recordProposal(record.WithError(err))
+		return err
 	}
 
 	if block == nil || block.Result.BlockID.Hash == "" {
 		p.metrics.Errors.Inc()
-		return fmt.Errorf("failed to get consensus block: invalid response")
+		err = fmt.Errorf("failed to get consensus block: invalid response")
+		p.recordProposal(record.WithError(err))
+		return err
 	}
 
+	record.BlockTime = block.Result.Block.Header.Time
 	if len(block.Result.Block.Data.Txs) == 0 {
+		record.EmptyConsensus = true
 		p.metrics.EmptyConsensusBlocks.Inc()
-		p.logger.WriteJSONLog("info", "Empty consensus block", map[string]interface{}{
+		p.logger.WriteJSONLog(logger.LevelInfo, "Empty consensus block", map[string]interface{}{
 			"height": clHeight,
 		}, nil)
 	}
 
-	foundBlock := false
-	for height := startHeight; height <= endHeight; height++ {
-		block, err := p.client.BlockByNumber(context.Background(), big.NewInt(height))
+	if payload := executionPayload(block); payload != nil {
+		p.checkSharedELBlock(clHeight, int64(payload.Number), payload.BlockHash.Hex())
+	}
+
+	scan := p.scanExecutionBlocks(clHeight, startHeight, endHeight)
+	if scan.block != nil {
+		elBlock, height := scan.block, scan.height
+		p.metrics.ExecutionConfirmed.Inc()
+		p.lastFoundELHeight = height // Save the found block height
+		record.Status = proposals.StatusConfirmed
+		record.ELHeight = height
+		record.Hash = elBlock.Hash().Hex()
+		p.logger.WriteJSONLog(logger.LevelSuccess, "Found execution block", map[string]interface{}{
+			"cl_height": clHeight,
+			"el_height": height,
+			"hash":      record.Hash,
+		}, nil)
+		p.checkSharedELBlock(clHeight, height, record.Hash)
+		scan.extra = p.findExtraELBlocks(scan)
+		p.checkExtraELBlocks(clHeight, scan)
+		p.verifyPayload(clHeight, block, elBlock)
+
+		reward, err := p.blockReward(elBlock)
 		if err != nil {
-			p.metrics.Errors.Inc()
-			p.logger.WriteJSONLog("error", "Failed to fetch block", map[string]interface{}{
+			p.rpcLogger.WriteJSONLog(logger.LevelWarn, "Failed to compute block fees", map[string]interface{}{
 				"height": height,
 			}, err)
-			continue
+		} else if reward != nil {
+			record.FeesWei = reward.String()
 		}
 
-		if block.Coinbase().Hex() == p.config.EVMAddress {
-			foundBlock = true
-			p.metrics.ExecutionConfirmed.Inc()
-			p.lastFoundELHeight = height // Save the found block height
-			p.logger.WriteJSONLog("success", "Found execution block", map[string]interface{}{
+		balance, err := p.trackRewards(elBlock, reward)
+		if err != nil {
+			p.rpcLogger.WriteJSONLog(logger.LevelWarn, "Failed to track fee recipient balance", map[string]interface{}{
+				"height": height,
+			}, err)
+		} else if balance != nil {
+			record.BalanceWei = balance.String()
+		}
+
+		p.headers.markOurs(height)
+		p.observeBlobs(p.config.TargetValidator, elBlock.Header())
+		p.trackBuilder(elBlock)
+
+		if len(elBlock.Transactions()) == 0 {
+			record.EmptyExecution = true
+			p.metrics.EmptyExecutionBlocks.Inc()
+			p.logger.WriteJSONLog(logger.LevelInfo, "Empty execution block", map[string]interface{}{
+				"height": height,
+			}, nil)
+		}
+	} else {
+		record.Status = proposals.StatusMissed
+		if p.upgradeHalted() {
+			// The execution block may never be built before the upgrade
+			record.MissReason = proposals.MissUpgradeHalt
+			p.recordProposal(record)
+			p.logger.WriteJSONLog(logger.LevelInfo, "Block not found in range during upgrade halt", map[string]interface{}{
 				"cl_height": clHeight,
-				"el_height": height,
-				"hash":      block.Hash().Hex(),
 			}, nil)
-
-			if len(block.Transactions()) == 0 {
-				p.metrics.EmptyExecutionBlocks.Inc()
-				p.logger.WriteJSONLog("info", "Empty execution block", map[string]interface{}{
-					"height": height,
-				}, nil)
-			}
-			break
+			return nil
 		}
-	}
 
-	if !foundBlock {
-		p.metrics.ExecutionMissed.Inc()
-		p.logger.WriteJSONLog("warn", "Block not found in range", map[string]interface{}{
+		reason := p.classifyMiss(record, expectedELHeight, scan)
+		record.MissReason = reason
+		p.metrics.ExecutionMissed.WithLabelValues(string(reason)).Inc()
+		if reason == proposals.MissOtherProposer || reason == proposals.MissUnknown {
+			p.metrics.ProposalsWithoutELBlock.Inc()
+			p.logger.WriteJSONLog(logger.LevelWarn, "Proposal has no execution block", map[string]interface{}{
+				"cl_height":          clHeight,
+				"expected_el_height": expectedELHeight,
+				"search_start":       startHeight - missSearchOffset,
+				"search_end":         endHeight + missSearchOffset,
+			}, nil)
+		}
+		p.logger.WriteJSONLog(logger.LevelWarn, "Block not found in range", map[string]interface{}{
+			"cl_height":    clHeight,
 			"start_height": startHeight,
 			"end_height":   endHeight,
+			"reason":       reason,
 		}, nil)
 	}
 
+	p.recordProposal(record)
 	return nil
 }
 
+// trackSigning checks whether our validator signed the previous height, using
+// the last commit carried by block.
+func (p *BlockProcessor) trackSigning(block *BlockResponse) {
+	commit := block.Result.Block.LastCommit
+	height, err := strconv.ParseInt(commit.Height, 10, 64)
+	if err != nil || height == 0 {
+		return // The first block has no last commit
+	}
+
+	sig, ok := commit.Signature(p.config.TargetValidator)
+	if !ok {
+		return // Not in the active set
+	}
+
+	if sig.Signed() {
+		p.metrics.SignedBlocks.Inc()
+		if sig.BlockIDFlag == BlockIDFlagNil {
+			p.metrics.NilVotes.Inc()
+		}
+	} else {
+		p.metrics.MissedSignatures.Inc()
+		p.logger.WriteJSONLog(logger.LevelWarn, "Missed signature", map[string]interface{}{
+			"height": height,
+		}, nil)
+	}
+
+	recorder, ok := p.proposals.(proposals.SigningRecorder)
+	if !ok {
+		return
+	}
+	signature := proposals.Signature{
+		Validator: p.config.TargetValidator,
+		Height:    height,
+		Signed:    sig.Signed(),
+		Time:      block.Result.Block.Header.Time,
+	}
+	if err := recorder.AddSignature(signature); err != nil {
+		p.logger.WriteJSONLog(logger.LevelError, "Failed to record signature", map[string]interface{}{
+			"height": height,
+		}, err)
+	}
+}
+
+// HeadClient is implemented by EL clients that report their latest block.
+// Scans aren't limited to the head for clients that don't.
+type HeadClient interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// elHead returns the latest execution height, or 0 when it is unknown.
+func (p *BlockProcessor) elHead() int64 {
+	client, ok := p.client.(HeadClient)
+	if !ok {
+		return 0
+	}
+	head, err := client.BlockNumber(context.Background())
+	if err != nil {
+		p.rpcLogger.WriteJSONLog(logger.LevelWarn, "Failed to get EL head", nil, err)
+		return 0
+	}
+	return int64(head)
+}
+
+// scanResult is the outcome of looking for our block in a range of execution
+// heights.
+type scanResult struct {
+	block     *types.Block
+	height    int64
+	end       int64            // Last height of the window, clamped to the EL head
+	extra     []*types.Block   // Further blocks with our coinbase after the match
+	coinbases map[int64]string // Coinbase of every block fetched
+	failed    int              // Heights that could not be fetched, past the head excluded
+}
+
+// scanExecutionBlocks fetches the execution blocks [start, end], recording
+// every attempt, until the first block of ours, built locally or by a builder
+// paying our fee recipient. Heights past the EL head are not produced yet and
+// are skipped rather than counted as failures.
+func (p *BlockProcessor) scanExecutionBlocks(clHeight, start, end int64) scanResult {
+	result := scanResult{coinbases: map[int64]string{}}
+	if head := p.elHead(); head > 0 && end > head {
+		end = head
+	}
+	result.end = end
+
+	for height := start; height <= end; height++ {
+		attempt := proposals.Attempt{
+			Validator: p.config.TargetValidator,
+			CLHeight:  clHeight,
+			ELHeight:  height,
+			Time:      time.Now().UTC(),
+		}
+
+		elBlock, err := p.client.BlockByNumber(context.Background(), big.NewInt(height))
+		if errors.Is(err, ethereum.NotFound) {
+			// The head moved back or the client has no head to clamp to, so
+			// this block and the ones after it don't exist yet
+			p.rpcLogger.WriteJSONLog(logger.LevelDebug, "Execution block not produced yet", map[string]interface{}{
+				"height": height,
+			}, nil)
+			break
+		}
+		if err != nil {
+			p.metrics.Errors.Inc()
+			p.rpcLogger.WriteJSONLog(logger.LevelError, "Failed to fetch block", map[string]interface{}{
+				"height": height,
+			}, err)
+			attempt.Error = err.Error()
+			p.recordAttempt(attempt)
+			p.recordError("rpc", "Failed to fetch block", height, err)
+			result.failed++
+			continue
+		}
+
+		p.headers.add(elBlock.Header())
+		attempt.Coinbase = elBlock.Coinbase().Hex()
+		attempt.Matched = p.isOurBlock(elBlock)
+		p.recordAttempt(attempt)
+		result.coinbases[height] = attempt.Coinbase
+
+		if attempt.Matched {
+			result.block = elBlock
+			result.height = height
+			break
+		}
+	}
+
+	return result
+}
+
+// missSearchOffset is how far beyond the scan window classifyMiss looks for
+// our block before deciding it was never produced.
+const missSearchOffset = 10
+
+// classifyMiss works out why no execution block was found for record. The
+// checks go from the most to the least certain cause. Only blocks that exist
+// but could not be fetched make the EL unreachable; the searches around the
+// window stop at the EL head like the scan itself.
+func (p *BlockProcessor) classifyMiss(record proposals.Record, expectedELHeight int64, scan scanResult) proposals.MissReason {
+	if record.EmptyConsensus {
+		return proposals.MissEmptyConsensus
+	}
+	if scan.failed > 0 {
+		return proposals.MissELUnreachable
+	}
+
+	// Look just outside the window in case the gap estimate was off
+	if wider := p.scanExecutionBlocks(record.CLHeight, record.ScanEnd+1, record.ScanEnd+missSearchOffset); wider.block != nil {
+		p.lastFoundELHeight = wider.height // Realign the next scan
+		return proposals.MissOutsideScanWindow
+	}
+	// Blocks up to the last one found are already accounted for
+	start := record.ScanStart - missSearchOffset
+	if start <= p.lastFoundELHeight {
+		start = p.lastFoundELHeight + 1
+	}
+	if start > 0 && start < record.ScanStart {
+		if earlier := p.scanExecutionBlocks(record.CLHeight, start, record.ScanStart-1); earlier.block != nil {
+			p.lastFoundELHeight = earlier.height
+			return proposals.MissOutsideScanWindow
+		}
+	}
+
+	if coinbase, ok := scan.coinbases[expectedELHeight]; ok && coinbase != p.config.EVMAddress {
+		return proposals.MissOtherProposer
+	}
+	return proposals.MissUnknown
+}
+
+// recordProposal hands the outcome of a proposal to the proposal store, if one
+// is configured.
+func (p *BlockProcessor) recordProposal(record proposals.Record) {
+	if p.proposals == nil {
+		return
+	}
+	if err := p.proposals.Add(record); err != nil {
+		p.logger.WriteJSONLog(logger.LevelError, "Failed to record proposal", map[string]interface{}{
+			"cl_height": record.CLHeight,
+		}, err)
+	}
+}
+
+// recordAttempt stores an EL match attempt if the proposal store keeps them.
+func (p *BlockProcessor) recordAttempt(attempt proposals.Attempt) {
+	recorder, ok := p.proposals.(proposals.AttemptRecorder)
+	if !ok {
+		return
+	}
+	if err := recorder.AddAttempt(attempt); err != nil {
+		p.logger.WriteJSONLog(logger.LevelError, "Failed to record match attempt", map[string]interface{}{
+			"cl_height": attempt.CLHeight,
+			"el_height": attempt.ELHeight,
+		}, err)
+	}
+}
+
+// recordError stores a processing error if the proposal store keeps them.
+func (p *BlockProcessor) recordError(component, message string, height int64, err error) {
+	recorder, ok := p.proposals.(proposals.ErrorRecorder)
+	if !ok {
+		return
+	}
+	event := proposals.ErrorEvent{
+		Height:    height,
+		Component: component,
+		Message:   message,
+		Error:     err.Error(),
+		Time:      time.Now().UTC(),
+	}
+	if err := recorder.AddError(event); err != nil {
+		p.logger.WriteJSONLog(logger.LevelError, "Failed to record error", nil, err)
+	}
+}
+
+// BlockTimes returns the times of the blocks seen by the processor.
+func (p *BlockProcessor) BlockTimes() *BlockTimes {
+	return p.blockTimes
+}
+
+// SetUpgradeHalt configures how the processor learns that the chain is
+// halted for an upgrade, so the halt is not reported as a stall.
+func (p *BlockProcessor) SetUpgradeHalt(upgrade UpgradeHalt) {
+	p.upgrade = upgrade
+}
+
+func (p *BlockProcessor) upgradeHalted() bool {
+	return p.upgrade != nil && p.upgrade.Halted()
+}
+
+// SetProposalStore configures where proposal outcomes are recorded.
+func (p *BlockProcessor) SetProposalStore(store proposals.Store) {
+	p.proposals = store
+}
+
 func (p *BlockProcessor) Start(ctx context.Context) {
 	var currentHeight int64
 	var errorBlocks int
@@ -159,7 +520,7 @@ func (p *BlockProcessor) Start(ctx context.Context) {
 				height, err := p.GetCurrentHeight()
 				if err != nil {
 					p.metrics.Errors.Inc()
-					p.logger.WriteJSONLog("error", "Failed to get current height", nil, err)
+					p.rpcLogger.WriteJSONLog(logger.LevelError, "Failed to get current height", nil, err)
 					time.Sleep(2 * time.Second)
 					continue
 				}
@@ -168,11 +529,19 @@ func (p *BlockProcessor) Start(ctx context.Context) {
 
 			// Get block
 			block, err := GetBlock(httpClient.NewClient(), p.config.RPCEndpoint, currentHeight)
+			if err != nil && p.upgradeHalted() {
+				p.rpcLogger.WriteJSONLog(logger.LevelDebug, "Waiting for the upgrade height", map[string]interface{}{
+					"height": currentHeight,
+				}, err)
+				time.Sleep(2 * time.Second)
+				continue
+			}
 			if err != nil {
 				p.metrics.Errors.Inc()
-				p.logger.WriteJSONLog("error", "Failed to get block", map[string]interface{}{
+				p.rpcLogger.WriteJSONLog(logger.LevelError, "Failed to get block", map[string]interface{}{
 					"height": currentHeight,
 				}, err)
+				p.recordError("rpc", "Failed to get block", currentHeight, err)
 				errorBlocks++
 				time.Sleep(2 * time.Second)
 				continue // Don't increment currentHeight on error
@@ -181,9 +550,10 @@ func (p *BlockProcessor) Start(ctx context.Context) {
 			// Process the block
 			if err := p.ProcessBlock(block); err != nil {
 				p.metrics.Errors.Inc()
-				p.logger.WriteJSONLog("error", "Error processing block", map[string]interface{}{
+				p.logger.WriteJSONLog(logger.LevelError, "Error processing block", map[string]interface{}{
 					"height": currentHeight,
 				}, err)
+				p.recordError("processor", "Error processing block", currentHeight, err)
 				errorBlocks++
 				// Only increment height if it's not our validator's block and block is valid
 				if err.Error() != "block is nil" && block != nil &&
@@ -201,6 +571,43 @@ func (p *BlockProcessor) Start(ctx context.Context) {
 	}
 }
 
+// Backfill processes the consensus heights [from, to] once, recording the
+// outcome of every proposal of the target validator in the proposal store.
+// EL heights come from the execution payloads of the blocks, so they don't
+// depend on today's gap; only proposals without a payload fall back to it.
+// Heights whose block can't be fetched are logged, recorded as errors and
+// returned, without stopping the backfill.
+func (p *BlockProcessor) Backfill(ctx context.Context, from, to int64) ([]int64, error) {
+	client := httpClient.NewClient()
+
+	var failed []int64
+	for height := from; height <= to; height++ {
+		if err := ctx.Err(); err != nil {
+			return failed, err
+		}
+
+		block, err := GetBlock(client, p.config.RPCEndpoint, height)
+		if err != nil {
+			p.metrics.Errors.Inc()
+			p.rpcLogger.WriteJSONLog(logger.LevelError, "Failed to get block", map[string]interface{}{
+				"height": height,
+			}, err)
+			p.recordError("rpc", "Failed to get block", height, err)
+			failed = append(failed, height)
+			continue
+		}
+
+		if err := p.ProcessBlock(block); err != nil {
+			p.logger.WriteJSONLog(logger.LevelError, "Error processing block", map[string]interface{}{
+				"height": height,
+			}, err)
+			p.recordError("processor", "Error processing block", height, err)
+		}
+	}
+
+	return failed, nil
+}
+
 // StartMetricsUpdater starts goroutines that continuously update metrics
 func (p *BlockProcessor) StartMetricsUpdater(ctx context.Context, interval time.Duration) {
 	// Current height updater
@@ -212,7 +619,7 @@ func (p *BlockProcessor) StartMetricsUpdater(ctx context.Context, interval time.
 			default:
 				height, err := p.GetCurrentHeight()
 				if err != nil {
-					p.logger.WriteJSONLog("error", "Failed to get current height", nil, err)
+					p.rpcLogger.WriteJSONLog(logger.LevelError, "Failed to get current height", nil, err)
 					p.metrics.Errors.Inc()
 				} else {
 					p.metrics.CurrentHeight.Set(float64(height))
@@ -231,7 +638,7 @@ func (p *BlockProcessor) StartMetricsUpdater(ctx context.Context, interval time.
 			default:
 				gap, err := p.GetCurrentGap()
 				if err != nil {
-					p.logger.WriteJSONLog("error", "Failed to get current gap", nil, err)
+					p.rpcLogger.WriteJSONLog(logger.LevelError, "Failed to get current gap", nil, err)
 					p.metrics.Errors.Inc()
 				} else {
 					p.metrics.ElToClGap.Set(float64(gap))
@@ -240,4 +647,20 @@ func (p *BlockProcessor) StartMetricsUpdater(ctx context.Context, interval time.
 			}
 		}
 	}()
+
+	// Blob metric updater
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				if err := p.updateBlobMetrics(); err != nil {
+					p.rpcLogger.WriteJSONLog(logger.LevelError, "Failed to update blob metrics", nil, err)
+					p.metrics.Errors.Inc()
+				}
+				time.Sleep(interval)
+			}
+		}
+	}()
 }
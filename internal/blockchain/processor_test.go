@@ -13,17 +13,29 @@ import (
 	"cosmos-evm-exporter/internal/config"
 	"cosmos-evm-exporter/internal/logger"
 	"cosmos-evm-exporter/internal/metrics"
+	"cosmos-evm-exporter/internal/proposals"
+	"cosmos-evm-exporter/internal/testchain"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 // MockEthClient implements EthClientInterface for testing
 type MockEthClient struct {
 	blocks map[int64]*types.Block
+	failAt map[int64]bool
+	head   int64 // Blocks past head don't exist yet, when set
 }
 
 func (m *MockEthClient) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	if m.failAt[number.Int64()] {
+		return nil, fmt.Errorf("connection refused")
+	}
+	if m.head > 0 && number.Int64() > m.head {
+		return nil, ethereum.NotFound
+	}
 	if block, ok := m.blocks[number.Int64()]; ok {
 		return block, nil
 	}
@@ -38,6 +50,15 @@ func (m *MockEthClient) BlockByNumber(ctx context.Context, number *big.Int) (*ty
 	return block, nil
 }
 
+// MockHeadClient adds BlockNumber to MockEthClient
+type MockHeadClient struct {
+	MockEthClient
+}
+
+func (m *MockHeadClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return uint64(m.head), nil
+}
+
 func TestProcessBlock(t *testing.T) {
 	testLogger := logger.NewLogger(&logger.Config{})
 	metrics := metrics.NewBlockMetrics()
@@ -54,14 +75,8 @@ func TestProcessBlock(t *testing.T) {
 		switch r.URL.Path {
 		case "/status":
 			json.NewEncoder(w).Encode(StatusResponse{
-				Result: struct {
-					SyncInfo struct {
-						LatestBlockHeight string `json:"latest_block_height"`
-					} `json:"sync_info"`
-				}{
-					SyncInfo: struct {
-						LatestBlockHeight string `json:"latest_block_height"`
-					}{
+				Result: StatusResult{
+					SyncInfo: SyncInfo{
 						LatestBlockHeight: "110",
 					},
 				},
@@ -109,122 +124,20 @@ func TestProcessBlock(t *testing.T) {
 			block: &BlockResponse{
 				JsonRPC: "2.0",
 				ID:      1,
-				Result: struct {
-					BlockID struct {
-						Hash  string `json:"hash"`
-						Parts struct {
-							Total int    `json:"total"`
-							Hash  string `json:"hash"`
-						} `json:"parts"`
-					} `json:"block_id"`
-					Block struct {
-						Header struct {
-							Version struct {
-								Block string `json:"block"`
-								App   string `json:"app"`
-							} `json:"version"`
-							ChainID     string    `json:"chain_id"`
-							Height      string    `json:"height"`
-							Time        time.Time `json:"time"`
-							LastBlockID struct {
-								Hash  string `json:"hash"`
-								Parts struct {
-									Total int    `json:"total"`
-									Hash  string `json:"hash"`
-								} `json:"parts"`
-							} `json:"last_block_id"`
-							LastCommitHash     string `json:"last_commit_hash"`
-							DataHash           string `json:"data_hash"`
-							ValidatorsHash     string `json:"validators_hash"`
-							NextValidatorsHash string `json:"next_validators_hash"`
-							ConsensusHash      string `json:"consensus_hash"`
-							AppHash            string `json:"app_hash"`
-							LastResultsHash    string `json:"last_results_hash"`
-							EvidenceHash       string `json:"evidence_hash"`
-							ProposerAddress    string `json:"proposer_address"`
-						} `json:"header"`
-						Data struct {
-							Txs []string `json:"txs"`
-						} `json:"data"`
-					} `json:"block"`
-				}{
-					BlockID: struct {
-						Hash  string `json:"hash"`
-						Parts struct {
-							Total int    `json:"total"`
-							Hash  string `json:"hash"`
-						} `json:"parts"`
-					}{
+				Result: BlockResult{
+					BlockID: BlockID{
 						Hash: "test_hash_123",
-						Parts: struct {
-							Total int    `json:"total"`
-							Hash  string `json:"hash"`
-						}{
+						Parts: PartSetHeader{
 							Total: 1,
 							Hash:  "parts_hash_123",
 						},
 					},
-					Block: struct {
-						Header struct {
-							Version struct {
-								Block string `json:"block"`
-								App   string `json:"app"`
-							} `json:"version"`
-							ChainID     string    `json:"chain_id"`
-							Height      string    `json:"height"`
-							Time        time.Time `json:"time"`
-							LastBlockID struct {
-								Hash  string `json:"hash"`
-								Parts struct {
-									Total int    `json:"total"`
-									Hash  string `json:"hash"`
-								} `json:"parts"`
-							} `json:"last_block_id"`
-							LastCommitHash     string `json:"last_commit_hash"`
-							DataHash           string `json:"data_hash"`
-							ValidatorsHash     string `json:"validators_hash"`
-							NextValidatorsHash string `json:"next_validators_hash"`
-							ConsensusHash      string `json:"consensus_hash"`
-							AppHash            string `json:"app_hash"`
-							LastResultsHash    string `json:"last_results_hash"`
-							EvidenceHash       string `json:"evidence_hash"`
-							ProposerAddress    string `json:"proposer_address"`
-						} `json:"header"`
-						Data struct {
-							Txs []string `json:"txs"`
-						} `json:"data"`
-					}{
-						Header: struct {
-							Version struct {
-								Block string `json:"block"`
-								App   string `json:"app"`
-							} `json:"version"`
-							ChainID     string    `json:"chain_id"`
-							Height      string    `json:"height"`
-							Time        time.Time `json:"time"`
-							LastBlockID struct {
-								Hash  string `json:"hash"`
-								Parts struct {
-									Total int    `json:"total"`
-									Hash  string `json:"hash"`
-								} `json:"parts"`
-							} `json:"last_block_id"`
-							LastCommitHash     string `json:"last_commit_hash"`
-							DataHash           string `json:"data_hash"`
-							ValidatorsHash     string `json:"validators_hash"`
-							NextValidatorsHash string `json:"next_validators_hash"`
-							ConsensusHash      string `json:"consensus_hash"`
-							AppHash            string `json:"app_hash"`
-							LastResultsHash    string `json:"last_results_hash"`
-							EvidenceHash       string `json:"evidence_hash"`
-							ProposerAddress    string `json:"proposer_address"`
-						}{
+					Block: Block{
+						Header: BlockHeader{
 							Height:          "100",
 							ProposerAddress: "validator1",
 						},
-						Data: struct {
-							Txs []string `json:"txs"`
-						}{
+						Data: BlockData{
 							Txs: []string{"tx1", "tx2"},
 						},
 					},
@@ -241,122 +154,20 @@ func TestProcessBlock(t *testing.T) {
 			block: &BlockResponse{
 				JsonRPC: "2.0",
 				ID:      1,
-				Result: struct {
-					BlockID struct {
-						Hash  string `json:"hash"`
-						Parts struct {
-							Total int    `json:"total"`
-							Hash  string `json:"hash"`
-						} `json:"parts"`
-					} `json:"block_id"`
-					Block struct {
-						Header struct {
-							Version struct {
-								Block string `json:"block"`
-								App   string `json:"app"`
-							} `json:"version"`
-							ChainID     string    `json:"chain_id"`
-							Height      string    `json:"height"`
-							Time        time.Time `json:"time"`
-							LastBlockID struct {
-								Hash  string `json:"hash"`
-								Parts struct {
-									Total int    `json:"total"`
-									Hash  string `json:"hash"`
-								} `json:"parts"`
-							} `json:"last_block_id"`
-							LastCommitHash     string `json:"last_commit_hash"`
-							DataHash           string `json:"data_hash"`
-							ValidatorsHash     string `json:"validators_hash"`
-							NextValidatorsHash string `json:"next_validators_hash"`
-							ConsensusHash      string `json:"consensus_hash"`
-							AppHash            string `json:"app_hash"`
-							LastResultsHash    string `json:"last_results_hash"`
-							EvidenceHash       string `json:"evidence_hash"`
-							ProposerAddress    string `json:"proposer_address"`
-						} `json:"header"`
-						Data struct {
-							Txs []string `json:"txs"`
-						} `json:"data"`
-					} `json:"block"`
-				}{
-					BlockID: struct {
-						Hash  string `json:"hash"`
-						Parts struct {
-							Total int    `json:"total"`
-							Hash  string `json:"hash"`
-						} `json:"parts"`
-					}{
+				Result: BlockResult{
+					BlockID: BlockID{
 						Hash: "test_hash_123",
-						Parts: struct {
-							Total int    `json:"total"`
-							Hash  string `json:"hash"`
-						}{
+						Parts: PartSetHeader{
 							Total: 1,
 							Hash:  "parts_hash_123",
 						},
 					},
-					Block: struct {
-						Header struct {
-							Version struct {
-								Block string `json:"block"`
-								App   string `json:"app"`
-							} `json:"version"`
-							ChainID     string    `json:"chain_id"`
-							Height      string    `json:"height"`
-							Time        time.Time `json:"time"`
-							LastBlockID struct {
-								Hash  string `json:"hash"`
-								Parts struct {
-									Total int    `json:"total"`
-									Hash  string `json:"hash"`
-								} `json:"parts"`
-							} `json:"last_block_id"`
-							LastCommitHash     string `json:"last_commit_hash"`
-							DataHash           string `json:"data_hash"`
-							ValidatorsHash     string `json:"validators_hash"`
-							NextValidatorsHash string `json:"next_validators_hash"`
-							ConsensusHash      string `json:"consensus_hash"`
-							AppHash            string `json:"app_hash"`
-							LastResultsHash    string `json:"last_results_hash"`
-							EvidenceHash       string `json:"evidence_hash"`
-							ProposerAddress    string `json:"proposer_address"`
-						} `json:"header"`
-						Data struct {
-							Txs []string `json:"txs"`
-						} `json:"data"`
-					}{
-						Header: struct {
-							Version struct {
-								Block string `json:"block"`
-								App   string `json:"app"`
-							} `json:"version"`
-							ChainID     string    `json:"chain_id"`
-							Height      string    `json:"height"`
-							Time        time.Time `json:"time"`
-							LastBlockID struct {
-								Hash  string `json:"hash"`
-								Parts struct {
-									Total int    `json:"total"`
-									Hash  string `json:"hash"`
-								} `json:"parts"`
-							} `json:"last_block_id"`
-							LastCommitHash     string `json:"last_commit_hash"`
-							DataHash           string `json:"data_hash"`
-							ValidatorsHash     string `json:"validators_hash"`
-							NextValidatorsHash string `json:"next_validators_hash"`
-							ConsensusHash      string `json:"consensus_hash"`
-							AppHash            string `json:"app_hash"`
-							LastResultsHash    string `json:"last_results_hash"`
-							EvidenceHash       string `json:"evidence_hash"`
-							ProposerAddress    string `json:"proposer_address"`
-						}{
+					Block: Block{
+						Header: BlockHeader{
 							Height:          "100",
 							ProposerAddress: "validator1",
 						},
-						Data: struct {
-							Txs []string `json:"txs"`
-						}{
+						Data: BlockData{
 							Txs: []string{},
 						},
 					},
@@ -389,11 +200,21 @@ func TestProcessBlock(t *testing.T) {
 				blocks: make(map[int64]*types.Block),
 			}
 
+			store := proposals.NewMemoryStore(10)
+			processor.SetProposalStore(store)
+
 			err = processor.ProcessBlock(tt.block)
 			if (err != nil) != tt.wantError {
 				t.Errorf("ProcessBlock() error = %v, wantError %v", err, tt.wantError)
 			}
 
+			if tt.wantProcessed {
+				records, _, _ := store.Query(proposals.Filter{})
+				if len(records) != 1 || records[0].CLHeight != 100 {
+					t.Errorf("Expected one proposal record for height 100, got %+v", records)
+				}
+			}
+
 			if tt.wantProcessed {
 				if len(tt.block.Result.Block.Data.Txs) > 0 != tt.expectTxs {
 					t.Errorf("ProcessBlock() transaction check failed, got %v transactions, expected transactions: %v",
@@ -403,3 +224,295 @@ func TestProcessBlock(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessBlockRecordsFailures(t *testing.T) {
+	blockTime := time.Date(2024, 11, 15, 10, 0, 0, 0, time.UTC)
+	block := &BlockResponse{Result: BlockResult{
+		BlockID: BlockID{Hash: "test_hash_123"},
+		Block: Block{
+			Header: BlockHeader{Height: "100", ProposerAddress: "validator1", Time: blockTime},
+			Data:   BlockData{Txs: []string{"tx1"}},
+		},
+	}}
+
+	// An EL that answers with garbage fails the gap before any scan
+	elServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"jsonrpc":"2.0","id":1,"result":"not a number"}`)
+	}))
+	defer elServer.Close()
+
+	processor, err := NewBlockProcessor(&config.Config{
+		TargetValidator: "validator1",
+		ETHEndpoint:     elServer.URL,
+	}, metrics.NewBlockMetrics(), newTestLogger())
+	if err != nil {
+		t.Fatalf("Failed to create processor: %v", err)
+	}
+	store := proposals.NewMemoryStore(10)
+	processor.SetProposalStore(store)
+
+	if err := processor.ProcessBlock(block); err == nil {
+		t.Fatal("Expected the gap to fail")
+	}
+
+	// The failed outcome is kept, and found by time filters
+	records, _, _ := store.Query(proposals.Filter{
+		FromTime: blockTime.Add(-time.Minute),
+		ToTime:   blockTime.Add(time.Minute),
+	})
+	if len(records) != 1 || records[0].Status != proposals.StatusError || !records[0].BlockTime.Equal(blockTime) {
+		t.Errorf("Expected an error record at the block time, got %+v", records)
+	}
+}
+
+func TestBackfillUsesPayloadHeights(t *testing.T) {
+	const ours, other = "AAAA", "BBBB"
+	scenario := testchain.Scenario{Start: 500, Gap: 10, Heights: []testchain.Height{
+		{Proposer: ours},
+		{Proposer: other},
+	}}
+	// Heights without execution blocks widen the gap by the time of the backfill
+	for i := 0; i < 8; i++ {
+		scenario.Heights = append(scenario.Heights, testchain.Height{Proposer: other, NoELBlock: true})
+	}
+	scenario.Heights = append(scenario.Heights, testchain.Height{Proposer: ours}, testchain.Height{Proposer: other})
+	chain := testchain.NewChain(scenario)
+	defer chain.Close()
+	chain.Advance(scenario.End())
+
+	processor, err := NewBlockProcessor(&config.Config{
+		TargetValidator: ours,
+		EVMAddress:      testchain.Coinbase(ours).Hex(),
+		ETHEndpoint:     chain.ETHEndpoint(),
+		RPCEndpoint:     chain.RPCEndpoint(),
+	}, metrics.NewBlockMetrics(), newTestLogger())
+	if err != nil {
+		t.Fatalf("Failed to create processor: %v", err)
+	}
+	store := proposals.NewMemoryStore(100)
+	processor.SetProposalStore(store)
+
+	failed, err := processor.Backfill(context.Background(), scenario.Start, scenario.End())
+	if err != nil || len(failed) != 0 {
+		t.Fatalf("Backfill() = %v, %v", failed, err)
+	}
+
+	records, _, _ := store.Query(proposals.Filter{})
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 proposals, got %+v", records)
+	}
+	for _, r := range records {
+		want, _ := chain.ELHeight(r.CLHeight)
+		if r.Status != proposals.StatusConfirmed || r.ELHeight != int64(want) {
+			t.Errorf("Height %d: expected confirmed at EL %d, got %s at %d (%s)", r.CLHeight, want, r.Status, r.ELHeight, r.MissReason)
+		}
+	}
+}
+
+func TestTrackSigning(t *testing.T) {
+	metrics := metrics.NewBlockMetrics()
+	processor, err := NewBlockProcessor(&config.Config{TargetValidator: "validator1", ETHEndpoint: "http://localhost:8545"}, metrics, newTestLogger())
+	if err != nil {
+		t.Fatalf("Failed to create processor: %v", err)
+	}
+	store := proposals.NewMemoryStore(10)
+	processor.SetProposalStore(store)
+
+	block := func(height string, sigs ...CommitSig) *BlockResponse {
+		return &BlockResponse{Result: BlockResult{Block: Block{
+			LastCommit: Commit{Height: height, Signatures: sigs},
+		}}}
+	}
+
+	processor.trackSigning(block("0"))
+	processor.trackSigning(block("99", CommitSig{BlockIDFlag: BlockIDFlagCommit, ValidatorAddress: "validator1"}))
+	processor.trackSigning(block("100", CommitSig{BlockIDFlag: BlockIDFlagAbsent, ValidatorAddress: "validator1"}))
+	processor.trackSigning(block("101", CommitSig{BlockIDFlag: BlockIDFlagCommit, ValidatorAddress: "validator2"}))
+	processor.trackSigning(block("98", CommitSig{BlockIDFlag: BlockIDFlagNil, ValidatorAddress: "validator1"}))
+
+	// A nil precommit is signed, like x/slashing counts it
+	if got := testutil.ToFloat64(metrics.SignedBlocks); got != 2 {
+		t.Errorf("Expected 2 signed blocks, got %v", got)
+	}
+	if got := testutil.ToFloat64(metrics.NilVotes); got != 1 {
+		t.Errorf("Expected 1 nil vote, got %v", got)
+	}
+	if got := testutil.ToFloat64(metrics.MissedSignatures); got != 1 {
+		t.Errorf("Expected 1 missed signature, got %v", got)
+	}
+
+	signatures, _, _ := store.Signatures(proposals.Filter{})
+	if len(signatures) != 3 || signatures[0].Height != 100 || signatures[0].Signed || !signatures[2].Signed {
+		t.Errorf("Expected signatures for 100 (missed), 99 and 98 (nil), got %+v", signatures)
+	}
+}
+
+func TestClassifyMiss(t *testing.T) {
+	ours := common.HexToAddress("0xabc")
+	blockWithCoinbase := func(height int64, coinbase common.Address) *types.Block {
+		return types.NewBlockWithHeader(&types.Header{Number: big.NewInt(height), Coinbase: coinbase})
+	}
+
+	tests := []struct {
+		name       string
+		record     proposals.Record
+		ourBlockAt int64
+		failAt     int64
+		want       proposals.MissReason
+	}{
+		{
+			name:   "empty consensus block",
+			record: proposals.Record{CLHeight: 100, ScanStart: 48, ScanEnd: 52, EmptyConsensus: true},
+			want:   proposals.MissEmptyConsensus,
+		},
+		{
+			name:   "EL unreachable",
+			record: proposals.Record{CLHeight: 100, ScanStart: 48, ScanEnd: 52},
+			failAt: 49,
+			want:   proposals.MissELUnreachable,
+		},
+		{
+			name:       "found after the window",
+			record:     proposals.Record{CLHeight: 100, ScanStart: 48, ScanEnd: 52},
+			ourBlockAt: 57,
+			want:       proposals.MissOutsideScanWindow,
+		},
+		{
+			name:       "found before the window",
+			record:     proposals.Record{CLHeight: 100, ScanStart: 48, ScanEnd: 52},
+			ourBlockAt: 40,
+			want:       proposals.MissOutsideScanWindow,
+		},
+		{
+			name:   "other proposer at expected height",
+			record: proposals.Record{CLHeight: 100, ScanStart: 48, ScanEnd: 52},
+			want:   proposals.MissOtherProposer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, err := NewBlockProcessor(&config.Config{
+				EVMAddress:  ours.Hex(),
+				ETHEndpoint: "http://localhost:8545",
+			}, metrics.NewBlockMetrics(), newTestLogger())
+			if err != nil {
+				t.Fatalf("Failed to create processor: %v", err)
+			}
+			client := &MockEthClient{blocks: make(map[int64]*types.Block), failAt: map[int64]bool{tt.failAt: true}}
+			if tt.ourBlockAt > 0 {
+				client.blocks[tt.ourBlockAt] = blockWithCoinbase(tt.ourBlockAt, ours)
+			}
+			processor.client = client
+
+			scan := processor.scanExecutionBlocks(tt.record.CLHeight, tt.record.ScanStart, tt.record.ScanEnd)
+			if scan.block != nil {
+				t.Fatalf("Expected no match in the window")
+			}
+			if got := processor.classifyMiss(tt.record, 50, scan); got != tt.want {
+				t.Errorf("classifyMiss() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyMissAtTip(t *testing.T) {
+	ours := common.HexToAddress("0xabc")
+	record := proposals.Record{CLHeight: 100, ScanStart: 48, ScanEnd: 52}
+
+	for name, client := range map[string]EthClientInterface{
+		// Blocks past the head are not found
+		"not found": &MockEthClient{blocks: make(map[int64]*types.Block), head: 50},
+		// The scans stop at the reported head
+		"clamped to the head": &MockHeadClient{MockEthClient{blocks: make(map[int64]*types.Block), head: 50}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			blockMetrics := metrics.NewBlockMetrics()
+			processor, err := NewBlockProcessor(&config.Config{
+				EVMAddress:  ours.Hex(),
+				ETHEndpoint: "http://localhost:8545",
+			}, blockMetrics, newTestLogger())
+			if err != nil {
+				t.Fatalf("Failed to create processor: %v", err)
+			}
+			processor.client = client
+
+			scan := processor.scanExecutionBlocks(record.CLHeight, record.ScanStart, record.ScanEnd)
+			if scan.failed != 0 || len(scan.coinbases) != 3 {
+				t.Errorf("Expected heights 48 to 50 fetched without failures, got %d failed and %v", scan.failed, scan.coinbases)
+			}
+			if got := processor.classifyMiss(record, 50, scan); got != proposals.MissOtherProposer {
+				t.Errorf("classifyMiss() = %s, want %s", got, proposals.MissOtherProposer)
+			}
+			if got := testutil.ToFloat64(blockMetrics.Errors); got != 0 {
+				t.Errorf("Expected no errors for heights past the head, got %v", got)
+			}
+		})
+	}
+}
+
+func TestCheckExecutionBlocksAtTip(t *testing.T) {
+	ours, other := common.HexToAddress("0xabc"), common.HexToAddress("0xdef")
+	clServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(BlockResponse{Result: BlockResult{
+			BlockID: BlockID{Hash: "hash_100"},
+			Block: Block{
+				Header: BlockHeader{Height: "100", ProposerAddress: "validator1"},
+				Data:   BlockData{Txs: []string{"tx1"}},
+			},
+		}})
+	}))
+	defer clServer.Close()
+
+	blocks := func() map[int64]*types.Block {
+		blocks := map[int64]*types.Block{}
+		for height := int64(96); height <= 100; height++ {
+			coinbase := other
+			if height == 98 {
+				coinbase = ours
+			}
+			blocks[height] = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(height), Coinbase: coinbase})
+		}
+		return blocks
+	}
+
+	for name, client := range map[string]EthClientInterface{
+		// Our block is the head, and the rest of the window isn't produced
+		"at the head": &MockHeadClient{MockEthClient{blocks: blocks(), head: 98}},
+		// Without a head, the window stops at the first block not found
+		"not found": &MockEthClient{blocks: blocks(), head: 98},
+		// Fetches after the match only look for duplicates
+		"failing after the match": &MockEthClient{blocks: blocks(), failAt: map[int64]bool{99: true, 100: true}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			blockMetrics := metrics.NewBlockMetrics()
+			processor, err := NewBlockProcessor(&config.Config{
+				TargetValidator: "validator1",
+				EVMAddress:      ours.Hex(),
+				ETHEndpoint:     "http://localhost:8545",
+				RPCEndpoint:     clServer.URL,
+			}, blockMetrics, newTestLogger())
+			if err != nil {
+				t.Fatalf("Failed to create processor: %v", err)
+			}
+			processor.client = client
+			store := proposals.NewMemoryStore(10)
+			processor.SetProposalStore(store)
+
+			if err := processor.checkExecutionBlocks(100, time.Now(), 98); err != nil {
+				t.Fatalf("checkExecutionBlocks() error = %v", err)
+			}
+
+			records, _, _ := store.Query(proposals.Filter{})
+			if len(records) != 1 || records[0].Status != proposals.StatusConfirmed || records[0].ELHeight != 98 {
+				t.Errorf("Expected proposal confirmed at 98, got %+v", records)
+			}
+			if got := testutil.ToFloat64(blockMetrics.Errors); got != 0 {
+				t.Errorf("Expected no errors, got %v", got)
+			}
+			if got := testutil.ToFloat64(blockMetrics.ProposalsWithMultipleELBlocks); got != 0 {
+				t.Errorf("Expected no duplicate blocks, got %v", got)
+			}
+		})
+	}
+}
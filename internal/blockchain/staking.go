@@ -0,0 +1,186 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/lcd"
+	"cosmos-evm-exporter/internal/logger"
+	"cosmos-evm-exporter/internal/metrics"
+)
+
+// bondStatuses maps the staking module statuses to their metric labels.
+var bondStatuses = map[string]string{
+	lcd.BondStatusBonded:    "bonded",
+	lcd.BondStatusUnbonding: "unbonding",
+	lcd.BondStatusUnbonded:  "unbonded",
+}
+
+// StakingClient is the part of the Cosmos REST API used by the staking
+// monitor.
+type StakingClient interface {
+	Validator(ctx context.Context, valoper string) (lcd.Validator, error)
+	SigningInfos(ctx context.Context) ([]lcd.SigningInfo, error)
+}
+
+// StakingMonitor exports the staking and slashing state of the configured
+// validators, which CometBFT RPC does not expose.
+type StakingMonitor struct {
+	config  *config.Config
+	metrics *metrics.CosmosMetrics
+	logger  *logger.Logger
+	client  StakingClient
+	jailed  map[string]bool
+}
+
+func NewStakingMonitor(config *config.Config, metrics *metrics.CosmosMetrics, logger *logger.Logger) *StakingMonitor {
+	return &StakingMonitor{
+		config:  config,
+		metrics: metrics,
+		logger:  logger.Component("staking"),
+		client:  lcd.NewClient(config.LCDEndpoint),
+		jailed:  map[string]bool{},
+	}
+}
+
+// Start polls the REST API every interval until ctx is done.
+func (m *StakingMonitor) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				m.updateValidators(ctx)
+				if err := m.updateSigningInfos(ctx); err != nil {
+					m.metrics.Errors.WithLabelValues("signing_infos").Inc()
+					m.logger.WriteJSONLog(logger.LevelError, "Failed to update signing infos", nil, err)
+				}
+				time.Sleep(interval)
+			}
+		}
+	}()
+}
+
+// updateValidators exports the staking state of every validator with an
+// operator address. A failed validator does not stop the others.
+func (m *StakingMonitor) updateValidators(ctx context.Context) {
+	for _, v := range m.config.Validators {
+		if v.OperatorAddress == "" {
+			continue
+		}
+		if err := m.updateValidator(ctx, v); err != nil {
+			m.metrics.Errors.WithLabelValues("validator").Inc()
+			m.logger.WriteJSONLog(logger.LevelError, "Failed to update validator", map[string]interface{}{
+				"validator": v.Label(),
+			}, err)
+		}
+	}
+}
+
+func (m *StakingMonitor) updateValidator(ctx context.Context, v config.ValidatorConfig) error {
+	validator, err := m.client.Validator(ctx, v.OperatorAddress)
+	if err != nil {
+		return err
+	}
+	label := v.Label()
+
+	for status, name := range bondStatuses {
+		value := 0.0
+		if status == validator.Status {
+			value = 1
+		}
+		m.metrics.Status.WithLabelValues(label, name).Set(value)
+	}
+
+	jailed := 0.0
+	if validator.Jailed {
+		jailed = 1
+	}
+	m.metrics.Jailed.WithLabelValues(label).Set(jailed)
+	if validator.Jailed != m.jailed[label] {
+		level, message := logger.LevelInfo, "Validator unjailed"
+		if validator.Jailed {
+			level, message = logger.LevelError, "Validator jailed"
+		}
+		m.logger.WriteJSONLog(level, message, map[string]interface{}{
+			"validator": label,
+			"status":    validator.Status,
+		}, nil)
+		m.jailed[label] = validator.Jailed
+	}
+
+	tokens, err := strconv.ParseFloat(validator.Tokens, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse tokens: %w", err)
+	}
+	m.metrics.Tokens.WithLabelValues(label).Set(tokens)
+
+	rate, err := strconv.ParseFloat(validator.Commission.CommissionRates.Rate, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse commission rate: %w", err)
+	}
+	m.metrics.CommissionRate.WithLabelValues(label).Set(rate)
+
+	return nil
+}
+
+// updateSigningInfos exports the slashing state of every validator with a
+// consensus address.
+func (m *StakingMonitor) updateSigningInfos(ctx context.Context) error {
+	wanted := map[string]string{}
+	for _, v := range m.config.Validators {
+		if v.ConsensusAddress != "" {
+			wanted[strings.ToUpper(strings.TrimPrefix(v.ConsensusAddress, "0x"))] = v.Label()
+		}
+	}
+	if len(wanted) == 0 {
+		return nil
+	}
+
+	infos, err := m.client.SigningInfos(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, info := range infos {
+		_, address, err := lcd.DecodeBech32(info.Address)
+		if err != nil {
+			m.logger.WriteJSONLog(logger.LevelDebug, "Skipping signing info", map[string]interface{}{
+				"address": info.Address,
+			}, err)
+			continue
+		}
+		key := strings.ToUpper(hex.EncodeToString(address))
+		label, ok := wanted[key]
+		if !ok {
+			continue
+		}
+		delete(wanted, key)
+
+		missed, err := strconv.ParseFloat(info.MissedBlocksCounter, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse missed blocks counter: %w", err)
+		}
+		m.metrics.MissedBlocks.WithLabelValues(label).Set(missed)
+
+		tombstoned := 0.0
+		if info.Tombstoned {
+			tombstoned = 1
+		}
+		m.metrics.Tombstoned.WithLabelValues(label).Set(tombstoned)
+		m.metrics.JailedUntil.WithLabelValues(label).Set(float64(info.JailedUntil.Unix()))
+	}
+
+	for _, label := range wanted {
+		m.logger.WriteJSONLog(logger.LevelWarn, "No signing info for validator", map[string]interface{}{
+			"validator": label,
+		}, nil)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/lcd"
+	"cosmos-evm-exporter/internal/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// MockStakingClient implements StakingClient for testing
+type MockStakingClient struct {
+	validators map[string]lcd.Validator
+	infos      []lcd.SigningInfo
+}
+
+func (m *MockStakingClient) Validator(ctx context.Context, valoper string) (lcd.Validator, error) {
+	validator, ok := m.validators[valoper]
+	if !ok {
+		return lcd.Validator{}, fmt.Errorf("validator does not exist")
+	}
+	return validator, nil
+}
+
+func (m *MockStakingClient) SigningInfos(ctx context.Context) ([]lcd.SigningInfo, error) {
+	return m.infos, nil
+}
+
+func TestStakingMonitor(t *testing.T) {
+	jailed := lcd.Validator{OperatorAddress: "evmvaloper1ours", Jailed: true, Status: lcd.BondStatusUnbonding, Tokens: "2500000"}
+	jailed.Commission.CommissionRates.Rate = "0.050000000000000000"
+	jailedUntil := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	stakingMetrics := metrics.NewCosmosMetrics(prometheus.NewRegistry())
+	monitor := &StakingMonitor{
+		config: &config.Config{Validators: []config.ValidatorConfig{
+			{Name: "ours", OperatorAddress: "evmvaloper1ours", ConsensusAddress: "0x5a2b6e1e3d0c4f7a8b9c0d1e2f3a4b5c6d7e8f90"},
+			{Name: "gone", OperatorAddress: "evmvaloper1gone"},
+		}},
+		metrics: stakingMetrics,
+		logger:  newTestLogger(),
+		client: &MockStakingClient{
+			validators: map[string]lcd.Validator{"evmvaloper1ours": jailed},
+			infos: []lcd.SigningInfo{
+				{Address: "not bech32", MissedBlocksCounter: "9"},
+				{Address: "cosmosvalcons1tg4ku83ap38h4zuup50z7wjtt3kharuslgh9sl", MissedBlocksCounter: "42", JailedUntil: jailedUntil},
+			},
+		},
+		jailed: map[string]bool{},
+	}
+
+	monitor.updateValidators(context.Background())
+	if err := monitor.updateSigningInfos(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	checks := map[string]struct {
+		metric prometheus.Collector
+		want   float64
+	}{
+		"bonded":          {stakingMetrics.Status.WithLabelValues("ours", "bonded"), 0},
+		"unbonding":       {stakingMetrics.Status.WithLabelValues("ours", "unbonding"), 1},
+		"jailed":          {stakingMetrics.Jailed.WithLabelValues("ours"), 1},
+		"tokens":          {stakingMetrics.Tokens.WithLabelValues("ours"), 2500000},
+		"commission":      {stakingMetrics.CommissionRate.WithLabelValues("ours"), 0.05},
+		"missed blocks":   {stakingMetrics.MissedBlocks.WithLabelValues("ours"), 42},
+		"jailed until":    {stakingMetrics.JailedUntil.WithLabelValues("ours"), float64(jailedUntil.Unix())},
+		"tombstoned":      {stakingMetrics.Tombstoned.WithLabelValues("ours"), 0},
+		"validator error": {stakingMetrics.Errors.WithLabelValues("validator"), 1},
+	}
+	for name, check := range checks {
+		if got := testutil.ToFloat64(check.metric); got != check.want {
+			t.Errorf("%s: expected %v, got %v", name, check.want, got)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/metrics"
+	"cosmos-evm-exporter/internal/proposals"
+	"cosmos-evm-exporter/internal/testchain"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestStartScenario(t *testing.T) {
+	const ours, other = "AAAA", "BBBB"
+	elDown := make([]testchain.Fault, 10)
+	for i := range elDown {
+		elDown[i] = testchain.FaultError
+	}
+	scenario := testchain.Scenario{
+		Start: 1000,
+		Gap:   10,
+		Lead:  3,
+		Heights: []testchain.Height{
+			{Proposer: other},
+			{Proposer: ours, ELTxs: 1},
+			{Proposer: other},
+			{Proposer: ours, Empty: true},
+			{Proposer: other, Absent: []string{ours}},
+			{Proposer: ours, NoELBlock: true},
+			{Proposer: other},
+			{Proposer: ours, Reorg: true},
+			{Proposer: other, CLFaults: []testchain.Fault{testchain.FaultMalformed}},
+			{Proposer: other},
+			{Proposer: other},
+			{Proposer: other},
+			{Proposer: ours, ELFaults: elDown}, // Out of the windows of the proposals before
+			{Proposer: other},
+			{Proposer: other},
+			{Proposer: other},
+		},
+	}
+	blockMetrics, outcomes := runStartScenario(t, scenario, ours)
+
+	want := map[int64]string{
+		1001: "confirmed/",
+		1003: "missed/" + string(proposals.MissEmptyConsensus),
+		1005: "missed/" + string(proposals.MissOtherProposer),
+		1007: "missed/" + string(proposals.MissOtherProposer),
+		1012: "missed/" + string(proposals.MissELUnreachable),
+	}
+	checkOutcomes(t, outcomes, want)
+
+	if got := testutil.ToFloat64(blockMetrics.ExecutionConfirmed); got != 1 {
+		t.Errorf("Expected 1 confirmed proposal, got %v", got)
+	}
+	if got := testutil.ToFloat64(blockMetrics.MissedSignatures); got != 1 {
+		t.Errorf("Expected 1 missed signature, got %v", got)
+	}
+	if got := testutil.ToFloat64(blockMetrics.EmptyConsensusBlocks); got != 1 {
+		t.Errorf("Expected 1 empty consensus block, got %v", got)
+	}
+	for _, field := range PayloadFields {
+		if got := testutil.ToFloat64(blockMetrics.PayloadMismatches.WithLabelValues(field)); got != 0 {
+			t.Errorf("Expected the confirmed payload to match, got %v mismatches on %s", got, field)
+		}
+	}
+}
+
+func TestStartScenarioAtTip(t *testing.T) {
+	const ours, other = "AAAA", "BBBB"
+	// Nothing is produced past the block being processed
+	scenario := testchain.Scenario{
+		Start: 2000,
+		Gap:   10,
+		Heights: []testchain.Height{
+			{Proposer: other},
+			{Proposer: ours, ELTxs: 1},
+			{Proposer: other},
+			{Proposer: ours},
+			{Proposer: ours, Empty: true},
+			{Proposer: other},
+			{Proposer: ours, NoELBlock: true},
+			{Proposer: other},
+			{Proposer: other},
+		},
+	}
+	blockMetrics, outcomes := runStartScenario(t, scenario, ours)
+
+	checkOutcomes(t, outcomes, map[int64]string{
+		2001: "confirmed/",
+		2003: "confirmed/",
+		2004: "missed/" + string(proposals.MissEmptyConsensus),
+		2006: "missed/" + string(proposals.MissOtherProposer),
+	})
+	if got := testutil.ToFloat64(blockMetrics.Errors); got != 0 {
+		t.Errorf("Expected no errors at the tip, got %v", got)
+	}
+	if got := testutil.ToFloat64(blockMetrics.ExecutionMissed.WithLabelValues(string(proposals.MissELUnreachable))); got != 0 {
+		t.Errorf("Expected no el_unreachable misses at the tip, got %v", got)
+	}
+	if got := testutil.ToFloat64(blockMetrics.ProposalsWithMultipleELBlocks); got != 0 {
+		t.Errorf("Expected no duplicate blocks, got %v", got)
+	}
+}
+
+// runStartScenario runs Start against a chain following scenario until every
+// height is processed, and returns the metrics and the outcome of each of our
+// proposals as status/miss_reason.
+func runStartScenario(t *testing.T, scenario testchain.Scenario, ours string) (*metrics.BlockMetrics, map[int64]string) {
+	t.Helper()
+	chain := testchain.NewChain(scenario)
+	defer chain.Close()
+
+	blockMetrics := metrics.NewBlockMetrics()
+	processor, err := NewBlockProcessor(&config.Config{
+		TargetValidator: ours,
+		EVMAddress:      testchain.Coinbase(ours).Hex(),
+		ETHEndpoint:     chain.ETHEndpoint(),
+		RPCEndpoint:     chain.RPCEndpoint(),
+	}, blockMetrics, newTestLogger())
+	if err != nil {
+		t.Fatalf("Failed to create processor: %v", err)
+	}
+	store := proposals.NewMemoryStore(100)
+	processor.SetProposalStore(store)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go processor.Start(ctx)
+
+	if !chain.WaitForBlock(scenario.End()+1, time.Minute) {
+		t.Fatalf("Scenario not processed, chain tip at %d", chain.Tip())
+	}
+	cancel()
+
+	records, _, err := store.Query(proposals.Filter{})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	outcomes := map[int64]string{}
+	for _, r := range records {
+		outcomes[r.CLHeight] = string(r.Status) + "/" + string(r.MissReason)
+	}
+	return blockMetrics, outcomes
+}
+
+func checkOutcomes(t *testing.T, outcomes, want map[int64]string) {
+	t.Helper()
+	if len(outcomes) != len(want) {
+		t.Errorf("Expected %d proposals, got %v", len(want), outcomes)
+	}
+	for height, outcome := range want {
+		if outcomes[height] != outcome {
+			t.Errorf("Height %d: expected %s, got %q", height, outcome, outcomes[height])
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Deposit is a deposit to the staking contract processed by the CL.
+type Deposit struct {
+	Pubkey      hexutil.Bytes `json:"pubkey"`
+	Credentials common.Hash   `json:"credentials"`
+	Amount      uint64        `json:"amount,string"`
+	Signature   hexutil.Bytes `json:"signature"`
+	Index       uint64        `json:"index,string"`
+}
+
+// BlobSidecar carries one blob of a block and its KZG commitment.
+type BlobSidecar struct {
+	Index         uint64        `json:"index,string"`
+	Blob          hexutil.Bytes `json:"blob"`
+	KZGCommitment hexutil.Bytes `json:"kzg_commitment"`
+	KZGProof      hexutil.Bytes `json:"kzg_proof"`
+}
+
+// ValidatorUpdate changes the voting power of a validator. A power of zero
+// removes it from the set.
+type ValidatorUpdate struct {
+	PubKey hexutil.Bytes `json:"pub_key"`
+	Power  int64         `json:"power,string"`
+}
+
+// CosmosTx is the part of a standard Cosmos SDK transaction the exporter
+// reports: its messages, memo and signature count.
+type CosmosTx struct {
+	Messages      []CosmosMsg
+	Memo          string
+	TimeoutHeight uint64
+	Signatures    int
+}
+
+// CosmosMsg is a message of a Cosmos SDK transaction, still encoded.
+type CosmosMsg struct {
+	TypeURL string
+	Value   []byte
+}
+
+func decodeExecutionPayload(payload []byte) (interface{}, error) {
+	var data engine.ExecutableData
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func decodeDeposits(payload []byte) (interface{}, error) {
+	var deposits []Deposit
+	if err := json.Unmarshal(payload, &deposits); err != nil {
+		return nil, err
+	}
+	return deposits, nil
+}
+
+func decodeBlobSidecars(payload []byte) (interface{}, error) {
+	var sidecars []BlobSidecar
+	if err := json.Unmarshal(payload, &sidecars); err != nil {
+		return nil, err
+	}
+	return sidecars, nil
+}
+
+func decodeValidatorUpdates(payload []byte) (interface{}, error) {
+	var updates []ValidatorUpdate
+	if err := json.Unmarshal(payload, &updates); err != nil {
+		return nil, err
+	}
+	return updates, nil
+}
+
+// DecodeCosmosTx decodes a protobuf TxRaw and its TxBody. Decoding is
+// strict, so framed transactions are not mistaken for Cosmos SDK ones.
+func DecodeCosmosTx(raw []byte) (*CosmosTx, error) {
+	tx := &CosmosTx{}
+	var body []byte
+	hasBody := false
+
+	err := walkFields(raw, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		if typ != protowire.BytesType {
+			return fmt.Errorf("unexpected wire type %d for field %d", typ, num)
+		}
+		switch num {
+		case 1: // body_bytes
+			body, hasBody = value, true
+		case 2: // auth_info_bytes
+		case 3: // signatures
+			tx.Signatures++
+		default:
+			return fmt.Errorf("unknown TxRaw field %d", num)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !hasBody {
+		return nil, fmt.Errorf("transaction has no body")
+	}
+
+	err = walkFields(body, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		switch {
+		case num == 1 && typ == protowire.BytesType: // messages
+			msg, err := decodeAny(value)
+			if err != nil {
+				return err
+			}
+			tx.Messages = append(tx.Messages, msg)
+		case num == 2 && typ == protowire.BytesType: // memo
+			tx.Memo = string(value)
+		case num == 3 && typ == protowire.VarintType: // timeout_height
+			tx.TimeoutHeight, _ = protowire.ConsumeVarint(value)
+		case num == 1023 || num == 2047: // extension options
+		default:
+			return fmt.Errorf("unknown TxBody field %d", num)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(tx.Messages) == 0 {
+		return nil, fmt.Errorf("transaction has no messages")
+	}
+	return tx, nil
+}
+
+// decodeAny decodes a google.protobuf.Any.
+func decodeAny(raw []byte) (CosmosMsg, error) {
+	var msg CosmosMsg
+	err := walkFields(raw, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		if typ != protowire.BytesType {
+			return fmt.Errorf("unexpected wire type %d in Any", typ)
+		}
+		switch num {
+		case 1:
+			msg.TypeURL = string(value)
+		case 2:
+			msg.Value = value
+		default:
+			return fmt.Errorf("unknown Any field %d", num)
+		}
+		return nil
+	})
+	if err != nil {
+		return CosmosMsg{}, err
+	}
+	if !strings.HasPrefix(msg.TypeURL, "/") {
+		return CosmosMsg{}, fmt.Errorf("invalid message type URL %q", msg.TypeURL)
+	}
+	return msg, nil
+}
+
+// walkFields calls visit with every field of a protobuf message. Bytes
+// fields get their content, varints their encoding.
+func walkFields(raw []byte, visit func(num protowire.Number, typ protowire.Type, value []byte) error) error {
+	for len(raw) > 0 {
+		num, typ, n := protowire.ConsumeTag(raw)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		raw = raw[n:]
+
+		var value []byte
+		switch typ {
+		case protowire.BytesType:
+			v, m := protowire.ConsumeBytes(raw)
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			value, n = v, m
+		default:
+			m := protowire.ConsumeFieldValue(num, typ, raw)
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			value, n = raw[:m], m
+		}
+		raw = raw[n:]
+
+		if err := visit(num, typ, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,12 +2,14 @@ package blockchain
 
 import (
 	"context"
+	"encoding/json"
 	"math/big"
 	"time"
 
 	"cosmos-evm-exporter/internal/config"
 	"cosmos-evm-exporter/internal/logger"
 	"cosmos-evm-exporter/internal/metrics"
+	"cosmos-evm-exporter/internal/proposals"
 
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/prometheus/client_golang/prometheus"
@@ -15,57 +17,199 @@ import (
 
 // BlockResponse defines the structure for consensus layer block API responses
 type BlockResponse struct {
-	JsonRPC string `json:"jsonrpc"`
-	ID      int    `json:"id"`
-	Result  struct {
-		BlockID struct {
-			Hash  string `json:"hash"`
-			Parts struct {
-				Total int    `json:"total"`
-				Hash  string `json:"hash"`
-			} `json:"parts"`
-		} `json:"block_id"`
-		Block struct {
-			Header struct {
-				Version struct {
-					Block string `json:"block"`
-					App   string `json:"app"`
-				} `json:"version"`
-				ChainID     string    `json:"chain_id"`
-				Height      string    `json:"height"`
-				Time        time.Time `json:"time"`
-				LastBlockID struct {
-					Hash  string `json:"hash"`
-					Parts struct {
-						Total int    `json:"total"`
-						Hash  string `json:"hash"`
-					} `json:"parts"`
-				} `json:"last_block_id"`
-				LastCommitHash     string `json:"last_commit_hash"`
-				DataHash           string `json:"data_hash"`
-				ValidatorsHash     string `json:"validators_hash"`
-				NextValidatorsHash string `json:"next_validators_hash"`
-				ConsensusHash      string `json:"consensus_hash"`
-				AppHash            string `json:"app_hash"`
-				LastResultsHash    string `json:"last_results_hash"`
-				EvidenceHash       string `json:"evidence_hash"`
-				ProposerAddress    string `json:"proposer_address"`
-			} `json:"header"`
-			Data struct {
-				Txs []string `json:"txs"`
-			} `json:"data"`
-		} `json:"block"`
-	} `json:"result"`
+	JsonRPC string      `json:"jsonrpc"`
+	ID      int         `json:"id"`
+	Result  BlockResult `json:"result"`
 }
 
-type StatusResponse struct {
+type BlockResult struct {
+	BlockID BlockID `json:"block_id"`
+	Block   Block   `json:"block"`
+}
+
+type BlockID struct {
+	Hash  string        `json:"hash"`
+	Parts PartSetHeader `json:"parts"`
+}
+
+type PartSetHeader struct {
+	Total int    `json:"total"`
+	Hash  string `json:"hash"`
+}
+
+type Block struct {
+	Header     BlockHeader  `json:"header"`
+	Data       BlockData    `json:"data"`
+	Evidence   EvidenceData `json:"evidence"`
+	LastCommit Commit       `json:"last_commit"`
+}
+
+// EvidenceData is the misbehaviour committed in a block.
+type EvidenceData struct {
+	Evidence []Evidence `json:"evidence"`
+}
+
+// Evidence is one piece of evidence in its amino JSON envelope. Value is
+// decoded according to Type.
+type Evidence struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+const (
+	EvidenceTypeDuplicateVote     = "tendermint/DuplicateVoteEvidence"
+	EvidenceTypeLightClientAttack = "tendermint/LightClientAttackEvidence"
+)
+
+// DuplicateVoteEvidence proves a validator signed two conflicting votes at
+// the same height and round.
+type DuplicateVoteEvidence struct {
+	VoteA EvidenceVote `json:"vote_a"`
+	VoteB EvidenceVote `json:"vote_b"`
+}
+
+type EvidenceVote struct {
+	Height           string `json:"height"`
+	Round            int    `json:"round"`
+	ValidatorAddress string `json:"validator_address"`
+}
+
+// LightClientAttackEvidence proves a set of validators signed a conflicting
+// header for light clients.
+type LightClientAttackEvidence struct {
+	CommonHeight        string `json:"CommonHeight"`
+	ByzantineValidators []struct {
+		Address string `json:"address"`
+	} `json:"ByzantineValidators"`
+}
+
+type BlockHeader struct {
+	Version struct {
+		Block string `json:"block"`
+		App   string `json:"app"`
+	} `json:"version"`
+	ChainID            string    `json:"chain_id"`
+	Height             string    `json:"height"`
+	Time               time.Time `json:"time"`
+	LastBlockID        BlockID   `json:"last_block_id"`
+	LastCommitHash     string    `json:"last_commit_hash"`
+	DataHash           string    `json:"data_hash"`
+	ValidatorsHash     string    `json:"validators_hash"`
+	NextValidatorsHash string    `json:"next_validators_hash"`
+	ConsensusHash      string    `json:"consensus_hash"`
+	AppHash            string    `json:"app_hash"`
+	LastResultsHash    string    `json:"last_results_hash"`
+	EvidenceHash       string    `json:"evidence_hash"`
+	ProposerAddress    string    `json:"proposer_address"`
+}
+
+type BlockData struct {
+	Txs []string `json:"txs"`
+}
+
+// Commit holds the precommits for the previous height included in a block.
+type Commit struct {
+	Height     string      `json:"height"`
+	Round      int         `json:"round"`
+	BlockID    BlockID     `json:"block_id"`
+	Signatures []CommitSig `json:"signatures"`
+}
+
+// Commit signature flags. A nil precommit is a vote for no block.
+const (
+	BlockIDFlagAbsent = 1
+	BlockIDFlagCommit = 2
+	BlockIDFlagNil    = 3
+)
+
+type CommitSig struct {
+	BlockIDFlag      int       `json:"block_id_flag"`
+	ValidatorAddress string    `json:"validator_address"`
+	Timestamp        time.Time `json:"timestamp"`
+	Signature        string    `json:"signature"`
+}
+
+// Signature returns the commit signature slot of address. The second result
+// is false when address is not in the validator set of the commit.
+func (c Commit) Signature(address string) (CommitSig, bool) {
+	for _, sig := range c.Signatures {
+		if sig.ValidatorAddress == address {
+			return sig, true
+		}
+	}
+	return CommitSig{}, false
+}
+
+// Signed reports whether the validator precommitted, for the committed block
+// or nil. Like x/slashing, only an absent validator missed the block.
+func (s CommitSig) Signed() bool {
+	return s.BlockIDFlag == BlockIDFlagCommit || s.BlockIDFlag == BlockIDFlagNil
+}
+
+// ConsensusStateResponse is the part of the CometBFT /consensus_state
+// response the round tracker reads.
+type ConsensusStateResponse struct {
 	Result struct {
-		SyncInfo struct {
-			LatestBlockHeight string `json:"latest_block_height"`
-		} `json:"sync_info"`
+		RoundState RoundState `json:"round_state"`
 	} `json:"result"`
 }
 
+type RoundState struct {
+	HeightRoundStep string        `json:"height/round/step"`
+	Proposer        RoundProposer `json:"proposer"`
+}
+
+type RoundProposer struct {
+	Address string `json:"address"`
+	Index   int    `json:"index"`
+}
+
+type StatusResponse struct {
+	Result StatusResult `json:"result"`
+}
+
+type StatusResult struct {
+	NodeInfo      NodeInfo      `json:"node_info"`
+	SyncInfo      SyncInfo      `json:"sync_info"`
+	ValidatorInfo ValidatorInfo `json:"validator_info"`
+}
+
+type NodeInfo struct {
+	ID      string `json:"id"`
+	Network string `json:"network"`
+	Version string `json:"version"`
+	Moniker string `json:"moniker"`
+}
+
+type SyncInfo struct {
+	LatestBlockHash   string    `json:"latest_block_hash"`
+	LatestBlockHeight string    `json:"latest_block_height"`
+	LatestBlockTime   time.Time `json:"latest_block_time"`
+	CatchingUp        bool      `json:"catching_up"`
+}
+
+type ValidatorInfo struct {
+	Address     string `json:"address"`
+	VotingPower string `json:"voting_power"`
+}
+
+// NetInfoResponse is the part of the CometBFT /net_info response the node
+// monitor reads.
+type NetInfoResponse struct {
+	Result NetInfoResult `json:"result"`
+}
+
+type NetInfoResult struct {
+	NPeers string `json:"n_peers"`
+	Peers  []Peer `json:"peers"`
+}
+
+type Peer struct {
+	NodeInfo   NodeInfo `json:"node_info"`
+	IsOutbound bool     `json:"is_outbound"`
+	RemoteIP   string   `json:"remote_ip"`
+}
+
 type EthRequest struct {
 	Jsonrpc string   `json:"jsonrpc"`
 	ID      string   `json:"id"`
@@ -103,11 +247,24 @@ type EthClientInterface interface {
 }
 
 type BlockProcessor struct {
-	config  *config.Config
-	metrics *metrics.BlockMetrics
-	client  EthClientInterface
-	logger  *logger.Logger
+	config            *config.Config
+	metrics           *metrics.BlockMetrics
+	client            EthClientInterface
+	logger            *logger.Logger
+	rpcLogger         *logger.Logger
+	decoderLogger     *logger.Logger
+	consensusLogger   *logger.Logger
+	proposals         proposals.Store
 	lastFoundELHeight int64
+	lastBalance       *big.Int // Fee recipient balance after our last block
+	blockTimes        *BlockTimes
+	upgrade           UpgradeHalt
+	elMatches         map[int64]elMatch // Recent EL blocks matched to our proposals
+	unknownMsgTypes   map[uint32]bool   // Message types already reported
+	lastBlobHeight    int64             // Last EL height observed for blob metrics
+	headers           *headerCache      // Headers of the EL blocks fetched by the scans
+	noBlobBaseFee     bool              // The EL doesn't know eth_blobBaseFee
+	builders          []builder
 }
 type EVMChainTx struct {
 	MsgType    uint32
@@ -0,0 +1,184 @@
+package blockchain
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/lcd"
+	"cosmos-evm-exporter/internal/logger"
+	"cosmos-evm-exporter/internal/metrics"
+)
+
+// UpgradeClient is the part of the Cosmos REST API used by the upgrade
+// monitor.
+type UpgradeClient interface {
+	CurrentPlan(ctx context.Context) (*lcd.Plan, error)
+}
+
+// UpgradeHalt reports whether the chain is stopped for a planned upgrade.
+type UpgradeHalt interface {
+	Halted() bool
+}
+
+// UpgradeMonitor follows the pending software upgrade. The chain stops after
+// the block before the upgrade height until the new binary produces the
+// upgrade height, and that window is reported as a halt instead of a stall.
+type UpgradeMonitor struct {
+	metrics    *metrics.CosmosMetrics
+	logger     *logger.Logger
+	client     UpgradeClient
+	blockTimes *BlockTimes
+
+	mu     sync.Mutex
+	plan   *lcd.Plan
+	height int64
+	halted bool
+}
+
+func NewUpgradeMonitor(config *config.Config, metrics *metrics.CosmosMetrics, blockTimes *BlockTimes, logger *logger.Logger) *UpgradeMonitor {
+	return &UpgradeMonitor{
+		metrics:    metrics,
+		logger:     logger.Component("upgrade"),
+		client:     lcd.NewClient(config.LCDEndpoint),
+		blockTimes: blockTimes,
+	}
+}
+
+// Start polls the upgrade plan every interval until ctx is done.
+func (m *UpgradeMonitor) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				m.update(ctx, time.Now())
+				time.Sleep(interval)
+			}
+		}
+	}()
+}
+
+// Halted reports whether the latest observed block is the last one before
+// the upgrade height. It turns false as soon as the processor sees the
+// upgrade height, without waiting for the next poll.
+func (m *UpgradeMonitor) Halted() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.plan == nil {
+		return false
+	}
+	latest, _, ok := m.blockTimes.Latest()
+	return ok && latest == m.height-1
+}
+
+func (m *UpgradeMonitor) update(ctx context.Context, now time.Time) {
+	plan, err := m.client.CurrentPlan(ctx)
+	if err != nil {
+		// The API usually goes down with the node during the upgrade
+		level := logger.LevelError
+		if m.Halted() {
+			level = logger.LevelDebug
+		} else {
+			m.metrics.Errors.WithLabelValues("current_plan").Inc()
+		}
+		m.logger.WriteJSONLog(level, "Failed to fetch upgrade plan", nil, err)
+	} else {
+		m.setPlan(plan)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	latest, latestTime, ok := m.blockTimes.Latest()
+	if m.plan == nil || !ok {
+		m.metrics.UpgradeHalted.Set(0)
+		return
+	}
+
+	// The upgrade applied once blocks advance past the halt
+	if latest >= m.height {
+		m.logger.WriteJSONLog(logger.LevelInfo, "Upgrade height reached, blocks advancing", map[string]interface{}{
+			"name":   m.plan.Name,
+			"height": m.height,
+		}, nil)
+		m.clearPlan()
+		return
+	}
+
+	halted := latest == m.height-1
+	if halted && !m.halted {
+		m.logger.WriteJSONLog(logger.LevelWarn, "Chain halted for upgrade, suppressing miss and stall alerts", map[string]interface{}{
+			"name":   m.plan.Name,
+			"height": m.height,
+		}, nil)
+	}
+	m.halted = halted
+	haltedValue := 0.0
+	if halted {
+		haltedValue = 1
+	}
+	m.metrics.UpgradeHalted.Set(haltedValue)
+
+	if average, ok := m.blockTimes.Average(); ok {
+		eta := latestTime.Add(time.Duration(m.height-latest) * average)
+		left := eta.Sub(now)
+		if left < 0 {
+			left = 0
+		}
+		m.metrics.UpgradeTimeLeft.Set(left.Seconds())
+	}
+}
+
+// setPlan replaces the known plan with the one reported by the API. A plan
+// only disappears before its height when governance cancels it; the node
+// clears it itself once the upgrade height is reached.
+func (m *UpgradeMonitor) setPlan(plan *lcd.Plan) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if plan == nil {
+		if m.plan != nil && !m.halted {
+			m.logger.WriteJSONLog(logger.LevelInfo, "Upgrade plan cleared", map[string]interface{}{
+				"name":   m.plan.Name,
+				"height": m.height,
+			}, nil)
+			m.clearPlan()
+		}
+		return
+	}
+
+	height, err := plan.HeightInt()
+	if err != nil {
+		m.metrics.Errors.WithLabelValues("current_plan").Inc()
+		m.logger.WriteJSONLog(logger.LevelError, "Failed to parse upgrade plan", nil, err)
+		return
+	}
+
+	if m.plan == nil || m.plan.Name != plan.Name || m.height != height {
+		m.logger.WriteJSONLog(logger.LevelInfo, "Upgrade planned", map[string]interface{}{
+			"name":   plan.Name,
+			"height": height,
+		}, nil)
+	}
+	m.plan = plan
+	m.height = height
+
+	m.metrics.UpgradePlan.Reset()
+	m.metrics.UpgradePlan.WithLabelValues(plan.Name).Set(1)
+	m.metrics.UpgradeHeight.Set(float64(height))
+}
+
+// clearPlan forgets the plan and resets its metrics. The caller holds mu.
+func (m *UpgradeMonitor) clearPlan() {
+	m.plan = nil
+	m.height = 0
+	m.halted = false
+	m.metrics.UpgradePlan.Reset()
+	m.metrics.UpgradeHeight.Set(0)
+	m.metrics.UpgradeTimeLeft.Set(0)
+	m.metrics.UpgradeHalted.Set(0)
+}
@@ -0,0 +1,170 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/lcd"
+	"cosmos-evm-exporter/internal/metrics"
+	"cosmos-evm-exporter/internal/proposals"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// MockUpgradeClient implements UpgradeClient for testing
+type MockUpgradeClient struct {
+	plan *lcd.Plan
+	err  error
+}
+
+func (m *MockUpgradeClient) CurrentPlan(ctx context.Context) (*lcd.Plan, error) {
+	return m.plan, m.err
+}
+
+func TestBlockTimes(t *testing.T) {
+	start := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	blockTimes := NewBlockTimes()
+
+	if _, ok := blockTimes.Average(); ok {
+		t.Error("expected no average before two blocks")
+	}
+	for i := int64(0); i < 150; i++ {
+		blockTimes.Observe(100+i, start.Add(time.Duration(i)*2*time.Second))
+	}
+	if average, ok := blockTimes.Average(); !ok || average != 2*time.Second {
+		t.Errorf("expected 2s average, got %v", average)
+	}
+
+	// Going backwards restarts the samples
+	blockTimes.Observe(10, start)
+	if height, _, _ := blockTimes.Latest(); height != 10 {
+		t.Errorf("expected latest height 10, got %d", height)
+	}
+	if _, ok := blockTimes.Average(); ok {
+		t.Error("expected no average after a restart")
+	}
+}
+
+func TestUpgradeMonitor(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	blockTimes := NewBlockTimes()
+	for height := int64(900); height <= 1000; height++ {
+		blockTimes.Observe(height, now.Add(time.Duration(height-1000)*2*time.Second))
+	}
+
+	client := &MockUpgradeClient{plan: &lcd.Plan{Name: "v2", Height: "1100"}}
+	cosmosMetrics := metrics.NewCosmosMetrics(prometheus.NewRegistry())
+	monitor := &UpgradeMonitor{
+		metrics:    cosmosMetrics,
+		logger:     newTestLogger(),
+		client:     client,
+		blockTimes: blockTimes,
+	}
+
+	monitor.update(context.Background(), now)
+	if got := testutil.ToFloat64(cosmosMetrics.UpgradeHeight); got != 1100 {
+		t.Errorf("expected upgrade height 1100, got %v", got)
+	}
+	if got := testutil.ToFloat64(cosmosMetrics.UpgradeTimeLeft); got != 200 {
+		t.Errorf("expected 200s left for 100 blocks of 2s, got %v", got)
+	}
+	if monitor.Halted() {
+		t.Error("expected no halt 100 blocks before the upgrade")
+	}
+
+	// The last block before the upgrade height, then the node goes down
+	blockTimes.Observe(1099, now.Add(198*time.Second))
+	client.plan, client.err = nil, fmt.Errorf("connection refused")
+	monitor.update(context.Background(), now.Add(time.Hour))
+	if !monitor.Halted() {
+		t.Error("expected a halt at the block before the upgrade height")
+	}
+	if got := testutil.ToFloat64(cosmosMetrics.UpgradeHalted); got != 1 {
+		t.Errorf("expected upgrade_halted 1, got %v", got)
+	}
+	if got := testutil.ToFloat64(cosmosMetrics.Errors.WithLabelValues("current_plan")); got != 0 {
+		t.Errorf("expected API errors during the halt to be ignored, got %v", got)
+	}
+
+	// The new binary produces the upgrade height
+	blockTimes.Observe(1100, now.Add(time.Hour))
+	if monitor.Halted() {
+		t.Error("expected the halt to end once blocks advance")
+	}
+	client.err = nil
+	monitor.update(context.Background(), now.Add(time.Hour))
+	if got := testutil.ToFloat64(cosmosMetrics.UpgradeHeight); got != 0 {
+		t.Errorf("expected the plan to be cleared, got height %v", got)
+	}
+	if got := testutil.CollectAndCount(cosmosMetrics.UpgradePlan); got != 0 {
+		t.Errorf("expected no plan info series, got %d", got)
+	}
+}
+
+// haltedUpgrade implements UpgradeHalt for testing
+type haltedUpgrade bool
+
+func (h haltedUpgrade) Halted() bool {
+	return bool(h)
+}
+
+func TestProcessBlockDuringUpgradeHalt(t *testing.T) {
+	block := &BlockResponse{Result: BlockResult{
+		BlockID: BlockID{Hash: "hash_1099"},
+		Block: Block{
+			Header: BlockHeader{Height: "1099", ProposerAddress: "validator1", Time: time.Now()},
+			Data:   BlockData{Txs: []string{"tx1"}},
+		},
+	}}
+	clServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/status":
+			json.NewEncoder(w).Encode(StatusResponse{Result: StatusResult{SyncInfo: SyncInfo{LatestBlockHeight: "1099"}}})
+		case "/block":
+			json.NewEncoder(w).Encode(block)
+		}
+	}))
+	defer clServer.Close()
+	elServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"jsonrpc":"2.0","id":1,"result":"0x64"}`)
+	}))
+	defer elServer.Close()
+
+	blockMetrics := metrics.NewBlockMetrics()
+	processor, err := NewBlockProcessor(&config.Config{
+		TargetValidator: "validator1",
+		EVMAddress:      "0x1234",
+		ETHEndpoint:     elServer.URL,
+		RPCEndpoint:     clServer.URL,
+	}, blockMetrics, newTestLogger())
+	if err != nil {
+		t.Fatalf("Failed to create processor: %v", err)
+	}
+	processor.client = &MockEthClient{blocks: make(map[int64]*types.Block)}
+	store := proposals.NewMemoryStore(10)
+	processor.SetProposalStore(store)
+	processor.SetUpgradeHalt(haltedUpgrade(true))
+
+	if err := processor.ProcessBlock(block); err != nil {
+		t.Fatalf("ProcessBlock() error = %v", err)
+	}
+
+	records, _, _ := store.Query(proposals.Filter{})
+	if len(records) != 1 || records[0].MissReason != proposals.MissUpgradeHalt {
+		t.Fatalf("Expected one upgrade_halt record, got %+v", records)
+	}
+	for _, reason := range proposals.MissReasons {
+		if got := testutil.ToFloat64(blockMetrics.ExecutionMissed.WithLabelValues(string(reason))); got != 0 {
+			t.Errorf("Expected no counted miss during the halt, got %v for %s", got, reason)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package blockchain
 
-import "fmt"
+import (
+	"fmt"
+
+	"cosmos-evm-exporter/internal/logger"
+)
 
 func (p *BlockProcessor) GetCurrentGap() (int64, error) {
 	elHeight, err := p.GetCurrentELHeight()
@@ -17,7 +21,12 @@ func (p *BlockProcessor) GetCurrentGap() (int64, error) {
 }
 
 func (p *BlockProcessor) DumpPayload(payload []byte) {
-	p.logger.WriteJSONLog("debug", "Payload dump", map[string]interface{}{
+	// Skip formatting the payload when the dump would be dropped
+	if !p.decoderLogger.Enabled(logger.LevelDebug) {
+		return
+	}
+
+	p.decoderLogger.WriteJSONLog(logger.LevelDebug, "Payload dump", map[string]interface{}{
 		"length": len(payload),
 		"hex":    fmt.Sprintf("%x", payload),
 	}, nil)
@@ -27,7 +36,7 @@ func (p *BlockProcessor) DumpPayload(payload []byte) {
 		if end > len(payload) {
 			end = len(payload)
 		}
-		p.logger.WriteJSONLog("debug", "Payload chunk", map[string]interface{}{
+		p.decoderLogger.WriteJSONLog(logger.LevelDebug, "Payload chunk", map[string]interface{}{
 			"start": i,
 			"end":   end - 1,
 			"hex":   fmt.Sprintf("%x", payload[i:end]),
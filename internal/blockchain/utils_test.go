@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/logger"
 	"cosmos-evm-exporter/internal/metrics"
 )
 
@@ -28,14 +29,8 @@ func TestDumpPayload(t *testing.T) {
 	clServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(StatusResponse{
-			Result: struct {
-				SyncInfo struct {
-					LatestBlockHeight string `json:"latest_block_height"`
-				} `json:"sync_info"`
-			}{
-				SyncInfo: struct {
-					LatestBlockHeight string `json:"latest_block_height"`
-				}{
+			Result: StatusResult{
+				SyncInfo: SyncInfo{
 					LatestBlockHeight: "7368349",
 				},
 			},
@@ -71,10 +66,14 @@ func TestDumpPayload(t *testing.T) {
 		},
 	}
 
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			// Just verify it doesn't panic
-			processor.DumpPayload(tc.payload)
-		})
+	// Dumps are skipped below debug, so run them at both levels
+	for _, level := range []logger.Level{logger.LevelInfo, logger.LevelDebug} {
+		processor.decoderLogger.SetLevel(level)
+		for _, tc := range testCases {
+			t.Run(level.String()+"/"+tc.name, func(t *testing.T) {
+				// Just verify it doesn't panic
+				processor.DumpPayload(tc.payload)
+			})
+		}
 	}
 }
@@ -0,0 +1,96 @@
+package blockchain
+
+import (
+	"context"
+	"math/big"
+
+	"cosmos-evm-exporter/internal/logger"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// PayloadFields are the fields of an execution payload compared with the EL
+// block at its number. A number mismatch means the block matched to the
+// proposal is not at the payload's height.
+var PayloadFields = []string{"number", "block_hash", "parent_hash", "state_root", "fee_recipient"}
+
+// executionPayload returns the first execution payload carried by block, or
+// nil when it has none.
+func executionPayload(block *BlockResponse) *engine.ExecutableData {
+	for _, txBase64 := range block.Result.Block.Data.Txs {
+		tx, err := DecodeCLTx(txBase64)
+		if err != nil {
+			continue
+		}
+		if data, ok := tx.Value.(*engine.ExecutableData); ok {
+			return data
+		}
+	}
+	return nil
+}
+
+// payloadMismatches returns the PayloadFields on which payload and elBlock
+// disagree, with the payload and EL values of each.
+func payloadMismatches(payload *engine.ExecutableData, elBlock *types.Block) map[string][2]string {
+	values := map[string][2]string{
+		"block_hash":    {payload.BlockHash.Hex(), elBlock.Hash().Hex()},
+		"parent_hash":   {payload.ParentHash.Hex(), elBlock.ParentHash().Hex()},
+		"state_root":    {payload.StateRoot.Hex(), elBlock.Root().Hex()},
+		"fee_recipient": {payload.FeeRecipient.Hex(), elBlock.Coinbase().Hex()},
+	}
+
+	mismatches := map[string][2]string{}
+	for field, pair := range values {
+		if pair[0] != pair[1] {
+			mismatches[field] = pair
+		}
+	}
+	return mismatches
+}
+
+// verifyPayload cross-checks the execution payload committed by our proposal
+// with the EL block at the payload's number. A mismatch means the EL node
+// follows a different fork than the one the proposal committed to. When the
+// block matched to the proposal is at another height, that is reported as a
+// number mismatch and the block at the payload's number is fetched instead.
+func (p *BlockProcessor) verifyPayload(clHeight int64, block *BlockResponse, matched *types.Block) {
+	payload := executionPayload(block)
+	if payload == nil {
+		return
+	}
+
+	mismatches := map[string][2]string{}
+	elBlock := matched
+	if matched.NumberU64() != payload.Number {
+		mismatches["number"] = [2]string{new(big.Int).SetUint64(payload.Number).String(), matched.Number().String()}
+
+		var err error
+		elBlock, err = p.client.BlockByNumber(context.Background(), new(big.Int).SetUint64(payload.Number))
+		if err != nil {
+			p.rpcLogger.WriteJSONLog(logger.LevelWarn, "Failed to fetch the payload's execution block", map[string]interface{}{
+				"cl_height": clHeight,
+				"el_height": payload.Number,
+			}, err)
+			elBlock = nil
+		}
+	}
+	if elBlock != nil {
+		for field, pair := range payloadMismatches(payload, elBlock) {
+			mismatches[field] = pair
+		}
+	}
+	if len(mismatches) == 0 {
+		return
+	}
+
+	fields := map[string]interface{}{
+		"cl_height": clHeight,
+		"el_height": payload.Number,
+	}
+	for field, pair := range mismatches {
+		p.metrics.PayloadMismatches.WithLabelValues(field).Inc()
+		fields[field] = map[string]string{"payload": pair[0], "el": pair[1]}
+	}
+	p.logger.WriteJSONLog(logger.LevelError, "Execution block does not match proposal payload", fields, nil)
+}
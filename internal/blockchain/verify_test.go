@@ -0,0 +1,92 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/metrics"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestVerifyPayload(t *testing.T) {
+	elBlock := types.NewBlockWithHeader(&types.Header{
+		Number:     big.NewInt(101),
+		ParentHash: common.HexToHash("0x01"),
+		Root:       common.HexToHash("0x02"),
+		Coinbase:   common.HexToAddress("0xabc"),
+	})
+	payloadBlock := func(payload engine.ExecutableData) *BlockResponse {
+		payload.BaseFeePerGas = big.NewInt(7)
+		payload.LogsBloom = make([]byte, 256)
+		payload.ExtraData = []byte{}
+		payload.Transactions = [][]byte{}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			t.Fatalf("Failed to encode payload: %v", err)
+		}
+		frame := make([]byte, 8+len(data))
+		binary.BigEndian.PutUint32(frame[0:4], MsgTypeExecutionPayload)
+		binary.BigEndian.PutUint32(frame[4:8], uint32(len(data)))
+		copy(frame[8:], data)
+		return &BlockResponse{Result: BlockResult{Block: Block{
+			Data: BlockData{Txs: []string{base64.StdEncoding.EncodeToString(frame)}},
+		}}}
+	}
+	matching := engine.ExecutableData{
+		Number:       101,
+		BlockHash:    elBlock.Hash(),
+		ParentHash:   elBlock.ParentHash(),
+		StateRoot:    elBlock.Root(),
+		FeeRecipient: elBlock.Coinbase(),
+	}
+	forked := matching
+	forked.BlockHash = common.HexToHash("0x03")
+	forked.StateRoot = common.HexToHash("0x04")
+	// Our coinbase one block early, the payload's block is the one at 101
+	earlier := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(100), Coinbase: common.HexToAddress("0xabc")})
+
+	tests := []struct {
+		name    string
+		block   *BlockResponse
+		matched *types.Block
+		want    map[string]float64
+	}{
+		{name: "matching payload", block: payloadBlock(matching), want: map[string]float64{}},
+		{name: "different fork", block: payloadBlock(forked), want: map[string]float64{"block_hash": 1, "state_root": 1}},
+		{name: "no payload", block: &BlockResponse{}, want: map[string]float64{}},
+		{name: "matched at another height", block: payloadBlock(matching), matched: earlier, want: map[string]float64{"number": 1}},
+		{name: "other height on a different fork", block: payloadBlock(forked), matched: earlier, want: map[string]float64{"number": 1, "block_hash": 1, "state_root": 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blockMetrics := metrics.NewBlockMetrics()
+			processor, err := NewBlockProcessor(&config.Config{ETHEndpoint: "http://localhost:8545"}, blockMetrics, newTestLogger())
+			if err != nil {
+				t.Fatalf("Failed to create processor: %v", err)
+			}
+
+			processor.client = &MockEthClient{blocks: map[int64]*types.Block{101: elBlock}}
+
+			matched := elBlock
+			if tt.matched != nil {
+				matched = tt.matched
+			}
+			processor.verifyPayload(100, tt.block, matched)
+
+			for _, field := range PayloadFields {
+				if got := testutil.ToFloat64(blockMetrics.PayloadMismatches.WithLabelValues(field)); got != tt.want[field] {
+					t.Errorf("Expected %v mismatches on %s, got %v", tt.want[field], field, got)
+				}
+			}
+		})
+	}
+}
@@ -10,9 +10,89 @@ type Config struct {
 	ETHEndpoint     string `toml:"eth_endpoint"`
 	RPCEndpoint     string `toml:"rpc_endpoint"`
 	MetricsPort     string `toml:"metrics_port"`
-	LogFile         string `toml:"log_file"`
-	EnableFileLog   bool   `toml:"enable_file_log"`
-	EnableStdout    bool   `toml:"enable_stdout"`
+	AdminAddress    string `toml:"admin_address"`
+	ExpectedChainID int64  `toml:"expected_chain_id"`
+
+	DisableTxPoolProbe bool   `toml:"disable_txpool_probe"`
+	EngineEndpoint     string `toml:"engine_endpoint"`
+	EngineJWTSecret    string `toml:"engine_jwt_secret"`
+	LCDEndpoint        string `toml:"lcd_endpoint"`
+	LogFile            string `toml:"log_file"`
+	EnableFileLog      bool   `toml:"enable_file_log"`
+	EnableStdout       bool   `toml:"enable_stdout"`
+
+	LogLevel  string            `toml:"log_level"`
+	LogLevels map[string]string `toml:"log_levels"`
+
+	LogMaxSizeMB   int    `toml:"log_max_size_mb"`
+	LogMaxAgeHours int    `toml:"log_max_age_hours"`
+	LogMaxBackups  int    `toml:"log_max_backups"`
+	LogCompress    bool   `toml:"log_compress"`
+	LogFileMode    string `toml:"log_file_mode"`
+
+	LogFormat string            `toml:"log_format"`
+	LogFields map[string]string `toml:"log_fields"`
+
+	ProposalHistorySize int `toml:"proposal_history_size"`
+
+	TrackConsensusRounds    bool `toml:"track_consensus_rounds"`
+	ConsensusPollIntervalMs int  `toml:"consensus_poll_interval_ms"`
+
+	Validators        []ValidatorConfig `toml:"validators"`
+	GovVoteAlertHours int               `toml:"gov_vote_alert_hours"`
+
+	EventWatches    []EventWatch `toml:"event_watches"`
+	EventBlockRange int          `toml:"event_block_range"`
+
+	Builders []BuilderConfig `toml:"builders"`
+
+	StorePath                 string `toml:"store_path"`
+	StoreRetentionDays        int    `toml:"store_retention_days"`
+	StoreAttemptRetentionDays int    `toml:"store_attempt_retention_days"`
+	StoreErrorRetentionDays   int    `toml:"store_error_retention_days"`
+	StoreSigningRetentionDays int    `toml:"store_signing_retention_days"`
+}
+
+// ValidatorConfig identifies a validator followed through the Cosmos REST
+// API. The consensus address is hex, like target_validator. The account
+// address votes on governance proposals and defaults to the account of the
+// operator address.
+type ValidatorConfig struct {
+	Name             string `toml:"name"`
+	OperatorAddress  string `toml:"operator_address"`
+	ConsensusAddress string `toml:"consensus_address"`
+	AccountAddress   string `toml:"account_address"`
+}
+
+// Label returns the name used for the validator in metrics and logs.
+func (v ValidatorConfig) Label() string {
+	if v.Name != "" {
+		return v.Name
+	}
+	if v.OperatorAddress != "" {
+		return v.OperatorAddress
+	}
+	return v.ConsensusAddress
+}
+
+// EventWatch selects the logs of one contract event to turn into metrics.
+type EventWatch struct {
+	Name      string            `toml:"name"`
+	Validator string            `toml:"validator"`
+	Contract  string            `toml:"contract"`
+	Event     string            `toml:"event"`
+	Topics    map[string]string `toml:"topics"`
+	SumField  string            `toml:"sum_field"`
+}
+
+// BuilderConfig recognizes the blocks of one external block builder. The
+// extra data pattern is a regular expression matched against the raw
+// extraData of the block, and the addresses are those the builder builds or
+// pays the fee recipient from.
+type BuilderConfig struct {
+	Name      string   `toml:"name"`
+	ExtraData string   `toml:"extra_data"`
+	Addresses []string `toml:"addresses"`
 }
 
 func LoadConfig(path string) (*Config, error) {
@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Capabilities are the Engine API methods announced in
+// engine_exchangeCapabilities.
+var Capabilities = []string{
+	"engine_newPayloadV1",
+	"engine_newPayloadV2",
+	"engine_newPayloadV3",
+	"engine_newPayloadV4",
+	"engine_forkchoiceUpdatedV1",
+	"engine_forkchoiceUpdatedV2",
+	"engine_forkchoiceUpdatedV3",
+	"engine_getPayloadV1",
+	"engine_getPayloadV2",
+	"engine_getPayloadV3",
+	"engine_getPayloadV4",
+	"engine_getPayloadBodiesByHashV1",
+	"engine_getPayloadBodiesByRangeV1",
+	"engine_getBlobsV1",
+}
+
+// Client calls the JWT authenticated Engine API of an execution client.
+type Client struct {
+	endpoint string
+	secret   []byte
+	http     *http.Client
+}
+
+func NewClient(endpoint string, secret []byte) *Client {
+	return &Client{
+		endpoint: endpoint,
+		secret:   secret,
+		http:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type request struct {
+	JsonRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type response struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (c *Client) call(ctx context.Context, method string, result interface{}, params ...interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	body, err := json.Marshal(request{JsonRPC: "2.0", ID: 1, Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	token, err := Token(c.secret, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to create JWT: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s failed: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s", method, resp.Status)
+	}
+
+	var rpcResp response
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", method, err)
+	}
+	if rpcResp.Error != nil {
+		return fmt.Errorf("%s failed: %s (%d)", method, rpcResp.Error.Message, rpcResp.Error.Code)
+	}
+	return json.Unmarshal(rpcResp.Result, result)
+}
+
+// ExchangeCapabilities announces Capabilities and returns the methods the
+// execution client supports.
+func (c *Client) ExchangeCapabilities(ctx context.Context) ([]string, error) {
+	var supported []string
+	err := c.call(ctx, "engine_exchangeCapabilities", &supported, Capabilities)
+	return supported, err
+}
+
+// Syncing reports whether eth_syncing over the auth port returns progress.
+func (c *Client) Syncing(ctx context.Context) (bool, error) {
+	var result json.RawMessage
+	if err := c.call(ctx, "eth_syncing", &result); err != nil {
+		return false, err
+	}
+	return string(result) != "false", nil
+}
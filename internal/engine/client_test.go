@@ -0,0 +1,103 @@
+package engine
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// newEngineServer stands in for an execution client's authrpc port.
+func newEngineServer(t *testing.T, syncing interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), ".")
+		if len(parts) != 3 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mac := hmac.New(sha256.New, testSecret)
+		mac.Write([]byte(parts[0] + "." + parts[1]))
+		if base64.RawURLEncoding.EncodeToString(mac.Sum(nil)) != parts[2] {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var req request
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var result interface{}
+		switch req.Method {
+		case "engine_exchangeCapabilities":
+			result = []string{"engine_newPayloadV3", "engine_forkchoiceUpdatedV3"}
+		case "eth_syncing":
+			result = syncing
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+}
+
+func TestClient(t *testing.T) {
+	server := newEngineServer(t, false)
+	defer server.Close()
+
+	client := NewClient(server.URL, testSecret)
+	capabilities, err := client.ExchangeCapabilities(context.Background())
+	if err != nil {
+		t.Fatalf("ExchangeCapabilities() error = %v", err)
+	}
+	if len(capabilities) != 2 || capabilities[0] != "engine_newPayloadV3" {
+		t.Errorf("Unexpected capabilities: %v", capabilities)
+	}
+
+	syncing, err := client.Syncing(context.Background())
+	if err != nil || syncing {
+		t.Errorf("Syncing() = %v, %v", syncing, err)
+	}
+
+	// A wrong secret is rejected
+	_, err = NewClient(server.URL, []byte("wrong-secret-wrong-secret-wrong!")).ExchangeCapabilities(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("Expected 401 error, got %v", err)
+	}
+}
+
+func TestToken(t *testing.T) {
+	now := time.Unix(1731668014, 0)
+	token, err := Token(testSecret, now)
+	if err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+
+	parts := strings.Split(token, ".")
+	claims, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	if string(claims) != `{"iat":1731668014}` {
+		t.Errorf("Unexpected claims %s", claims)
+	}
+}
+
+func TestLoadJWTSecret(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "jwt.hex")
+	os.WriteFile(valid, []byte("0x"+strings.Repeat("ab", 32)+"\n"), 0600)
+	secret, err := LoadJWTSecret(valid)
+	if err != nil || len(secret) != 32 {
+		t.Errorf("LoadJWTSecret() = %x, %v", secret, err)
+	}
+
+	short := filepath.Join(dir, "short.hex")
+	os.WriteFile(short, []byte("abcd"), 0600)
+	if _, err := LoadJWTSecret(short); err == nil {
+		t.Error("Expected an error for a short secret")
+	}
+}
@@ -0,0 +1,52 @@
+package engine
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// LoadJWTSecret reads the hex encoded 32 byte secret shared by the consensus
+// and execution clients, the file passed to --authrpc.jwtsecret.
+func LoadJWTSecret(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWT secret: %w", err)
+	}
+
+	secret, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode JWT secret: %w", err)
+	}
+	if len(secret) != 32 {
+		return nil, fmt.Errorf("JWT secret must be 32 bytes, got %d", len(secret))
+	}
+	return secret, nil
+}
+
+// Token returns an HS256 token with the iat claim the Engine API requires.
+// Execution clients reject tokens whose iat is more than 60 seconds off, so
+// a new token is made for every request.
+func Token(secret []byte, now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]int64{"iat": now.Unix()})
+	if err != nil {
+		return "", err
+	}
+
+	encoding := base64.RawURLEncoding
+	unsigned := encoding.EncodeToString(header) + "." + encoding.EncodeToString(claims)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + encoding.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,138 @@
+package events
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ParseEvent parses a Solidity event signature such as
+// "Distributed(address indexed validator, uint256 amount)". Unnamed
+// parameters are called arg0, arg1 and so on. Tuple parameters are not
+// supported.
+func ParseEvent(signature string) (abi.Event, error) {
+	signature = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(signature), "event "))
+	open := strings.Index(signature, "(")
+	if open <= 0 || !strings.HasSuffix(signature, ")") {
+		return abi.Event{}, fmt.Errorf("invalid event signature %q", signature)
+	}
+	name := signature[:open]
+
+	var inputs abi.Arguments
+	if params := strings.TrimSpace(signature[open+1 : len(signature)-1]); params != "" {
+		for i, param := range strings.Split(params, ",") {
+			fields := strings.Fields(param)
+			if len(fields) == 0 {
+				return abi.Event{}, fmt.Errorf("empty parameter %d in %q", i, signature)
+			}
+
+			typ, err := abi.NewType(fields[0], "", nil)
+			if err != nil {
+				return abi.Event{}, fmt.Errorf("parameter %d: %w", i, err)
+			}
+			arg := abi.Argument{Name: "arg" + strconv.Itoa(i), Type: typ}
+			for _, field := range fields[1:] {
+				if field == "indexed" {
+					arg.Indexed = true
+				} else {
+					arg.Name = field
+				}
+			}
+			inputs = append(inputs, arg)
+		}
+	}
+
+	return abi.NewEvent(name, name, false, inputs), nil
+}
+
+// Topics builds the eth_getLogs topic filter for event: its ID, then the
+// values of the indexed parameters named in filters. Parameters without a
+// filter match anything.
+func Topics(event abi.Event, filters map[string]string) ([][]common.Hash, error) {
+	topics := [][]common.Hash{{event.ID}}
+
+	used := 0
+	var pending [][]common.Hash
+	for _, arg := range event.Inputs {
+		if !arg.Indexed {
+			continue
+		}
+		value, ok := filters[arg.Name]
+		if !ok {
+			pending = append(pending, nil)
+			continue
+		}
+
+		parsed, err := topicValue(arg.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("filter %s: %w", arg.Name, err)
+		}
+		hashes, err := abi.MakeTopics([]interface{}{parsed})
+		if err != nil {
+			return nil, fmt.Errorf("filter %s: %w", arg.Name, err)
+		}
+		topics = append(topics, pending...)
+		topics = append(topics, hashes[0])
+		pending = nil
+		used++
+	}
+
+	if used != len(filters) {
+		return nil, fmt.Errorf("filters must name indexed parameters of %s", event.Sig)
+	}
+	return topics, nil
+}
+
+// topicValue converts a filter value from the config into the Go type
+// abi.MakeTopics expects for typ.
+func topicValue(typ abi.Type, value string) (interface{}, error) {
+	switch typ.T {
+	case abi.AddressTy:
+		if !common.IsHexAddress(value) {
+			return nil, fmt.Errorf("invalid address %q", value)
+		}
+		return common.HexToAddress(value), nil
+	case abi.IntTy, abi.UintTy:
+		n, ok := new(big.Int).SetString(value, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", value)
+		}
+		return n, nil
+	case abi.BoolTy:
+		return strconv.ParseBool(value)
+	case abi.FixedBytesTy:
+		if typ.Size != 32 {
+			break
+		}
+		return common.HexToHash(value), nil
+	case abi.StringTy:
+		return value, nil
+	}
+	return nil, fmt.Errorf("unsupported indexed type %s", typ.String())
+}
+
+// Decode returns the parameters of log by name, indexed ones included.
+func Decode(event abi.Event, log types.Log) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if err := event.Inputs.NonIndexed().UnpackIntoMap(fields, log.Data); err != nil {
+		return nil, fmt.Errorf("failed to decode data: %w", err)
+	}
+
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if len(log.Topics) > 0 {
+		if err := abi.ParseTopicsIntoMap(fields, indexed, log.Topics[1:]); err != nil {
+			return nil, fmt.Errorf("failed to decode topics: %w", err)
+		}
+	}
+	return fields, nil
+}
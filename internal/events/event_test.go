@@ -0,0 +1,140 @@
+package events
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestParseEvent(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature string
+		wantSig   string
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name:      "named and indexed",
+			signature: "Distributed(address indexed validator, uint256 amount)",
+			wantSig:   "Distributed(address,uint256)",
+			wantNames: []string{"validator", "amount"},
+		},
+		{
+			name:      "event keyword and unnamed parameter",
+			signature: "event Slashed(bytes32 indexed, uint256)",
+			wantSig:   "Slashed(bytes32,uint256)",
+			wantNames: []string{"arg0", "arg1"},
+		},
+		{
+			name:      "no parameters",
+			signature: "Paused()",
+			wantSig:   "Paused()",
+		},
+		{
+			name:      "missing parentheses",
+			signature: "Distributed",
+			wantErr:   true,
+		},
+		{
+			name:      "unknown type",
+			signature: "Distributed(decimal amount)",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := ParseEvent(tt.signature)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for %q", tt.signature)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if event.Sig != tt.wantSig {
+				t.Errorf("expected signature %s, got %s", tt.wantSig, event.Sig)
+			}
+			if want := crypto.Keccak256Hash([]byte(tt.wantSig)); event.ID != want {
+				t.Errorf("expected ID %s, got %s", want.Hex(), event.ID.Hex())
+			}
+			if len(event.Inputs) != len(tt.wantNames) {
+				t.Fatalf("expected %d inputs, got %d", len(tt.wantNames), len(event.Inputs))
+			}
+			for i, name := range tt.wantNames {
+				if event.Inputs[i].Name != name {
+					t.Errorf("input %d: expected name %s, got %s", i, name, event.Inputs[i].Name)
+				}
+			}
+		})
+	}
+}
+
+func TestTopics(t *testing.T) {
+	event, err := ParseEvent("Transfer(address indexed from, address indexed to, uint256 value)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	to := common.HexToAddress("0x1234567890123456789012345678901234567890")
+
+	topics, err := Topics(event, map[string]string{"to": to.Hex()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The unfiltered "from" topic matches anything
+	if len(topics) != 3 {
+		t.Fatalf("expected 3 topic positions, got %d", len(topics))
+	}
+	if topics[0][0] != event.ID {
+		t.Errorf("expected event ID as first topic")
+	}
+	if topics[1] != nil {
+		t.Errorf("expected wildcard for from, got %v", topics[1])
+	}
+	if topics[2][0] != common.BytesToHash(to.Bytes()) {
+		t.Errorf("expected padded address for to, got %s", topics[2][0].Hex())
+	}
+
+	if _, err := Topics(event, map[string]string{"value": "1"}); err == nil {
+		t.Error("expected an error filtering on a non-indexed parameter")
+	}
+	if _, err := Topics(event, map[string]string{"to": "not an address"}); err == nil {
+		t.Error("expected an error for an invalid address")
+	}
+}
+
+func TestDecode(t *testing.T) {
+	event, err := ParseEvent("Distributed(address indexed validator, uint256 amount)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	validator := common.HexToAddress("0x1234567890123456789012345678901234567890")
+
+	data, err := abi.Arguments{event.Inputs[1]}.Pack(big.NewInt(5e18))
+	if err != nil {
+		t.Fatalf("failed to pack data: %v", err)
+	}
+	fields, err := Decode(event, types.Log{
+		Topics: []common.Hash{event.ID, common.BytesToHash(validator.Bytes())},
+		Data:   data,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if fields["validator"] != validator {
+		t.Errorf("expected validator %s, got %v", validator.Hex(), fields["validator"])
+	}
+	if amount, ok := fields["amount"].(*big.Int); !ok || amount.Cmp(big.NewInt(5e18)) != 0 {
+		t.Errorf("expected amount 5e18, got %v", fields["amount"])
+	}
+}
@@ -0,0 +1,266 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/logger"
+	"cosmos-evm-exporter/internal/metrics"
+	"cosmos-evm-exporter/internal/rpc"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const DefaultBlockRange = 1000
+
+// LogClient is the part of the execution client used to fetch logs.
+type LogClient interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// watch is an EventWatch with its event and filter resolved.
+type watch struct {
+	config.EventWatch
+	event    abi.Event
+	contract common.Address
+	topics   [][]common.Hash
+}
+
+// Watcher follows the execution chain and turns the logs selected by the
+// configured event watches into metrics.
+type Watcher struct {
+	metrics    *metrics.EventMetrics
+	logger     *logger.Logger
+	client     LogClient
+	watches    []watch
+	blockRange uint64
+	next       uint64
+}
+
+func NewWatcher(config *config.Config, metrics *metrics.EventMetrics, logger *logger.Logger) (*Watcher, error) {
+	watches, err := compile(config)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := rpc.NewClient(config.ETHEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	blockRange := uint64(DefaultBlockRange)
+	if config.EventBlockRange > 0 {
+		blockRange = uint64(config.EventBlockRange)
+	}
+
+	return &Watcher{
+		metrics:    metrics,
+		logger:     logger.Component("events"),
+		client:     client,
+		watches:    watches,
+		blockRange: blockRange,
+	}, nil
+}
+
+// compile parses the event signatures and filters of every configured watch.
+func compile(cfg *config.Config) ([]watch, error) {
+	var watches []watch
+	for i, w := range cfg.EventWatches {
+		if w.Name == "" {
+			return nil, fmt.Errorf("event watch %d has no name", i)
+		}
+		if !common.IsHexAddress(w.Contract) {
+			return nil, fmt.Errorf("event watch %s: invalid contract address %q", w.Name, w.Contract)
+		}
+
+		event, err := ParseEvent(w.Event)
+		if err != nil {
+			return nil, fmt.Errorf("event watch %s: %w", w.Name, err)
+		}
+		topics, err := Topics(event, w.Topics)
+		if err != nil {
+			return nil, fmt.Errorf("event watch %s: %w", w.Name, err)
+		}
+		if w.SumField != "" {
+			if err := checkSumField(event, w.SumField); err != nil {
+				return nil, fmt.Errorf("event watch %s: %w", w.Name, err)
+			}
+		}
+		if w.Validator == "" {
+			w.Validator = cfg.TargetValidator
+		}
+
+		watches = append(watches, watch{
+			EventWatch: w,
+			event:      event,
+			contract:   common.HexToAddress(w.Contract),
+			topics:     topics,
+		})
+	}
+	return watches, nil
+}
+
+// checkSumField requires the summed parameter to be an unsigned integer:
+// sums are exported as counters, which cannot go down.
+func checkSumField(event abi.Event, name string) error {
+	for _, arg := range event.Inputs {
+		if arg.Name != name {
+			continue
+		}
+		if arg.Type.T != abi.UintTy {
+			return fmt.Errorf("%s parameter %s is %s, only unsigned integers can be summed", event.Sig, name, arg.Type.String())
+		}
+		return nil
+	}
+	return fmt.Errorf("%s has no parameter %s", event.Sig, name)
+}
+
+// sumValue converts a decoded unsigned integer to the float added to the sum.
+// The ABI decoder returns uint8 to uint64 as Go integers and wider ones as
+// *big.Int.
+func sumValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case *big.Int:
+		if v.Sign() < 0 {
+			return 0, false
+		}
+		sum, _ := new(big.Float).SetInt(v).Float64()
+		return sum, true
+	}
+	return 0, false
+}
+
+// Start scans new blocks every interval until ctx is done. Scanning starts at
+// the head when the watcher starts; earlier events are not replayed.
+func (w *Watcher) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				if err := w.poll(ctx); err != nil {
+					w.metrics.QueryErrors.Inc()
+					w.logger.WriteJSONLog(logger.LevelError, "Failed to fetch contract events", nil, err)
+				}
+				time.Sleep(interval)
+			}
+		}
+	}()
+}
+
+// poll scans the blocks up to the current head in chunks of blockRange. A
+// failed chunk is retried from its first block on the next poll.
+func (w *Watcher) poll(ctx context.Context) error {
+	head, err := w.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch head: %w", err)
+	}
+	if w.next == 0 {
+		w.next = head
+	}
+
+	for w.next <= head {
+		to := w.next + w.blockRange - 1
+		if to > head {
+			to = head
+		}
+
+		// Fetch every watch before recording any, so a retried chunk is not
+		// counted twice
+		matched := make([][]types.Log, len(w.watches))
+		for i, watch := range w.watches {
+			logs, err := w.fetch(ctx, watch, w.next, to)
+			if err != nil {
+				return fmt.Errorf("event watch %s, blocks %d-%d: %w", watch.Name, w.next, to, err)
+			}
+			matched[i] = logs
+		}
+		for i, logs := range matched {
+			for _, log := range logs {
+				if !log.Removed {
+					w.record(w.watches[i], log)
+				}
+			}
+		}
+
+		w.next = to + 1
+		w.metrics.LastBlock.Set(float64(to))
+	}
+	return nil
+}
+
+// fetch returns the logs of watch between from and to.
+func (w *Watcher) fetch(ctx context.Context, watch watch, from, to uint64) ([]types.Log, error) {
+	return w.client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{watch.contract},
+		Topics:    watch.topics,
+	})
+}
+
+func (w *Watcher) record(watch watch, log types.Log) {
+	fields, err := Decode(watch.event, log)
+	if err != nil {
+		w.logger.WriteJSONLog(logger.LevelWarn, "Failed to decode contract event", map[string]interface{}{
+			"watch":     watch.Name,
+			"el_height": log.BlockNumber,
+			"tx_hash":   log.TxHash.Hex(),
+		}, err)
+		return
+	}
+
+	w.metrics.Events.WithLabelValues(watch.Name, watch.Validator).Inc()
+	if watch.SumField != "" {
+		if sum, ok := sumValue(fields[watch.SumField]); ok {
+			w.metrics.Values.WithLabelValues(watch.Name, watch.Validator, watch.SumField).Add(sum)
+		}
+	}
+
+	values := map[string]interface{}{}
+	for name, value := range fields {
+		values[name] = formatValue(value)
+	}
+	w.logger.WriteJSONLog(logger.LevelInfo, "Contract event", map[string]interface{}{
+		"watch":     watch.Name,
+		"validator": watch.Validator,
+		"event":     watch.event.Name,
+		"el_height": log.BlockNumber,
+		"tx_hash":   log.TxHash.Hex(),
+		"fields":    values,
+	}, nil)
+}
+
+// formatValue renders decoded values the way they appear in explorers.
+func formatValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *big.Int:
+		return v.String()
+	case common.Address:
+		return v.Hex()
+	case common.Hash:
+		return v.Hex()
+	case [32]byte:
+		return common.Hash(v).Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	}
+	return value
+}
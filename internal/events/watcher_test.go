@@ -0,0 +1,215 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"cosmos-evm-exporter/internal/config"
+	"cosmos-evm-exporter/internal/logger"
+	"cosmos-evm-exporter/internal/metrics"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// MockLogClient implements LogClient for testing
+type MockLogClient struct {
+	head    uint64
+	logs    []types.Log
+	fail    bool
+	queries []ethereum.FilterQuery
+}
+
+func (m *MockLogClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return m.head, nil
+}
+
+func (m *MockLogClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	m.queries = append(m.queries, query)
+	if m.fail {
+		return nil, fmt.Errorf("connection refused")
+	}
+
+	var logs []types.Log
+	for _, log := range m.logs {
+		if log.BlockNumber >= query.FromBlock.Uint64() && log.BlockNumber <= query.ToBlock.Uint64() {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+func TestWatcherPoll(t *testing.T) {
+	contract := "0x00000000000000000000000000000000000000aa"
+	cfg := &config.Config{
+		TargetValidator: "VALIDATOR",
+		EventWatches: []config.EventWatch{{
+			Name:     "rewards",
+			Contract: contract,
+			Event:    "Distributed(address indexed validator, uint256 amount)",
+			Topics:   map[string]string{"validator": "0x1234567890123456789012345678901234567890"},
+			SumField: "amount",
+		}},
+	}
+	watches, err := compile(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	distributed := func(number uint64, amount int64) types.Log {
+		data, err := abi.Arguments{watches[0].event.Inputs[1]}.Pack(big.NewInt(amount))
+		if err != nil {
+			t.Fatalf("failed to pack data: %v", err)
+		}
+		topics := []common.Hash{watches[0].event.ID, watches[0].topics[1][0]}
+		return types.Log{BlockNumber: number, Topics: topics, Data: data}
+	}
+
+	client := &MockLogClient{head: 100}
+	eventMetrics := metrics.NewEventMetrics(prometheus.NewRegistry())
+	watcher := &Watcher{
+		metrics:    eventMetrics,
+		logger:     logger.NewLogger(&logger.Config{}),
+		client:     client,
+		watches:    watches,
+		blockRange: 10,
+	}
+
+	// The first poll starts at the head
+	if err := watcher.poll(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(client.queries) != 1 || client.queries[0].FromBlock.Uint64() != 100 {
+		t.Fatalf("expected a single query from the head, got %+v", client.queries)
+	}
+
+	// A failed chunk is retried on the next poll
+	client.head = 125
+	client.fail = true
+	if err := watcher.poll(context.Background()); err == nil {
+		t.Fatal("expected an error")
+	}
+	if watcher.next != 101 {
+		t.Fatalf("expected to resume at 101, got %d", watcher.next)
+	}
+
+	client.fail = false
+	client.queries = nil
+	client.logs = []types.Log{distributed(105, 2e9), distributed(120, 3e9), {BlockNumber: 121, Removed: true}}
+	if err := watcher.poll(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(client.queries) != 3 {
+		t.Errorf("expected 3 chunks for blocks 101-125, got %d", len(client.queries))
+	}
+	query := client.queries[0]
+	if query.Addresses[0] != common.HexToAddress(contract) {
+		t.Errorf("expected query for %s, got %v", contract, query.Addresses)
+	}
+	if got := testutil.ToFloat64(eventMetrics.Events.WithLabelValues("rewards", "VALIDATOR")); got != 2 {
+		t.Errorf("expected 2 events, got %v", got)
+	}
+	if got := testutil.ToFloat64(eventMetrics.Values.WithLabelValues("rewards", "VALIDATOR", "amount")); got != 5e9 {
+		t.Errorf("expected amount sum 5e9, got %v", got)
+	}
+	if got := testutil.ToFloat64(eventMetrics.LastBlock); got != 125 {
+		t.Errorf("expected last block 125, got %v", got)
+	}
+}
+
+func TestCompile(t *testing.T) {
+	valid := config.EventWatch{
+		Name:     "rewards",
+		Contract: "0x00000000000000000000000000000000000000aa",
+		Event:    "Distributed(address indexed validator, uint256 amount)",
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(w *config.EventWatch)
+		wantErr bool
+	}{
+		{name: "valid", modify: func(w *config.EventWatch) {}},
+		{name: "missing name", modify: func(w *config.EventWatch) { w.Name = "" }, wantErr: true},
+		{name: "invalid contract", modify: func(w *config.EventWatch) { w.Contract = "0x12" }, wantErr: true},
+		{name: "unknown sum field", modify: func(w *config.EventWatch) { w.SumField = "value" }, wantErr: true},
+		{name: "uint64 sum field", modify: func(w *config.EventWatch) {
+			w.Event = "Distributed(address indexed validator, uint64 amount)"
+			w.SumField = "amount"
+		}},
+		{name: "signed sum field", modify: func(w *config.EventWatch) {
+			w.Event = "Distributed(address indexed validator, int256 amount)"
+			w.SumField = "amount"
+		}, wantErr: true},
+		{name: "address sum field", modify: func(w *config.EventWatch) { w.SumField = "validator" }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := valid
+			tt.modify(&w)
+			_, err := compile(&config.Config{EventWatches: []config.EventWatch{w}})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRecordSumsIntegerKinds(t *testing.T) {
+	for _, tt := range []struct {
+		typ   string
+		value interface{}
+	}{
+		{typ: "uint8", value: uint8(200)},
+		{typ: "uint32", value: uint32(4e9)},
+		{typ: "uint64", value: uint64(1e18)},
+		{typ: "uint256", value: new(big.Int).Lsh(big.NewInt(1), 70)},
+	} {
+		t.Run(tt.typ, func(t *testing.T) {
+			watches, err := compile(&config.Config{
+				TargetValidator: "VALIDATOR",
+				EventWatches: []config.EventWatch{{
+					Name:     "rewards",
+					Contract: "0x00000000000000000000000000000000000000aa",
+					Event:    fmt.Sprintf("Distributed(%s amount)", tt.typ),
+					SumField: "amount",
+				}},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			data, err := watches[0].event.Inputs.Pack(tt.value)
+			if err != nil {
+				t.Fatalf("failed to pack data: %v", err)
+			}
+
+			eventMetrics := metrics.NewEventMetrics(prometheus.NewRegistry())
+			watcher := &Watcher{metrics: eventMetrics, logger: logger.NewLogger(&logger.Config{})}
+			log := types.Log{Topics: []common.Hash{watches[0].event.ID}, Data: data}
+			watcher.record(watches[0], log)
+			watcher.record(watches[0], log)
+
+			want, _ := sumValue(tt.value)
+			if got := testutil.ToFloat64(eventMetrics.Values.WithLabelValues("rewards", "VALIDATOR", "amount")); got != 2*want || want == 0 {
+				t.Errorf("expected amount sum %v, got %v", 2*want, got)
+			}
+		})
+	}
+}
+
+func TestSumValueRejectsNegative(t *testing.T) {
+	if _, ok := sumValue(big.NewInt(-5)); ok {
+		t.Error("expected a negative int256 not to be summed")
+	}
+	if _, ok := sumValue(int64(5)); ok {
+		t.Error("expected a signed integer not to be summed")
+	}
+}
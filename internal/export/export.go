@@ -0,0 +1,131 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"cosmos-evm-exporter/internal/proposals"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+const (
+	FormatCSV     = "csv"
+	FormatParquet = "parquet"
+)
+
+// Row is the flat representation of a proposal record used for exports.
+type Row struct {
+	Validator      string `parquet:"validator"`
+	CLHeight       int64  `parquet:"cl_height"`
+	ELHeight       int64  `parquet:"el_height,optional"`
+	Hash           string `parquet:"hash,optional"`
+	Status         string `parquet:"status"`
+	MissReason     string `parquet:"miss_reason,optional"`
+	EmptyConsensus bool   `parquet:"empty_consensus"`
+	EmptyExecution bool   `parquet:"empty_execution"`
+	FeesWei        string `parquet:"fees_wei,optional"`
+	BalanceWei     string `parquet:"balance_wei,optional"`
+	BlockTime      int64  `parquet:"block_time,timestamp(millisecond)"`
+	ObservedAt     int64  `parquet:"observed_at,timestamp(millisecond)"`
+}
+
+var csvHeader = []string{
+	"validator", "cl_height", "el_height", "hash", "status", "miss_reason",
+	"empty_consensus", "empty_execution", "fees_wei", "balance_wei", "block_time", "observed_at",
+}
+
+// NewRow flattens a proposal record.
+func NewRow(record proposals.Record) Row {
+	return Row{
+		Validator:      record.Validator,
+		CLHeight:       record.CLHeight,
+		ELHeight:       record.ELHeight,
+		Hash:           record.Hash,
+		Status:         string(record.Status),
+		MissReason:     string(record.MissReason),
+		EmptyConsensus: record.EmptyConsensus,
+		EmptyExecution: record.EmptyExecution,
+		FeesWei:        record.FeesWei,
+		BalanceWei:     record.BalanceWei,
+		BlockTime:      unixMilli(record.BlockTime),
+		ObservedAt:     unixMilli(record.ObservedAt),
+	}
+}
+
+func unixMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+func formatMilli(ms int64) string {
+	if ms == 0 {
+		return ""
+	}
+	return time.UnixMilli(ms).UTC().Format(time.RFC3339)
+}
+
+// Write writes records to w in the given format.
+func Write(w io.Writer, format string, records []proposals.Record) error {
+	rows := make([]Row, len(records))
+	for i, record := range records {
+		rows[i] = NewRow(record)
+	}
+
+	switch format {
+	case FormatCSV:
+		return WriteCSV(w, rows)
+	case FormatParquet:
+		return WriteParquet(w, rows)
+	}
+	return fmt.Errorf("unknown export format %q", format)
+}
+
+// WriteCSV writes rows as CSV with a header line. Times are RFC3339.
+func WriteCSV(w io.Writer, rows []Row) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		elHeight := ""
+		if row.ELHeight > 0 {
+			elHeight = strconv.FormatInt(row.ELHeight, 10)
+		}
+		err := cw.Write([]string{
+			row.Validator,
+			strconv.FormatInt(row.CLHeight, 10),
+			elHeight,
+			row.Hash,
+			row.Status,
+			row.MissReason,
+			strconv.FormatBool(row.EmptyConsensus),
+			strconv.FormatBool(row.EmptyExecution),
+			row.FeesWei,
+			row.BalanceWei,
+			formatMilli(row.BlockTime),
+			formatMilli(row.ObservedAt),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteParquet writes rows as a single Parquet file.
+func WriteParquet(w io.Writer, rows []Row) error {
+	writer := parquet.NewGenericWriter[Row](w)
+	if _, err := writer.Write(rows); err != nil {
+		return err
+	}
+	return writer.Close()
+}
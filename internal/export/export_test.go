@@ -0,0 +1,92 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"cosmos-evm-exporter/internal/proposals"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func testRecords() []proposals.Record {
+	blockTime := time.Date(2024, 11, 15, 10, 53, 34, 0, time.UTC)
+	return []proposals.Record{
+		{
+			Validator:  "B2A5C37E",
+			CLHeight:   7458296,
+			ELHeight:   6892471,
+			Hash:       "0xcf98",
+			Status:     proposals.StatusConfirmed,
+			FeesWei:    "21000000000000",
+			BalanceWei: "5021000000000000",
+			BlockTime:  blockTime,
+			ObservedAt: blockTime.Add(time.Second),
+		},
+		{
+			Validator:      "B2A5C37E",
+			CLHeight:       7458300,
+			Status:         proposals.StatusMissed,
+			MissReason:     proposals.MissOutsideScanWindow,
+			EmptyConsensus: true,
+			BlockTime:      blockTime.Add(time.Minute),
+		},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatCSV, testRecords()); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	lines, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+	if len(lines) != 3 {
+		t.Fatalf("Expected header and 2 rows, got %d lines", len(lines))
+	}
+
+	confirmed := lines[1]
+	if confirmed[1] != "7458296" || confirmed[2] != "6892471" || confirmed[8] != "21000000000000" || confirmed[9] != "5021000000000000" {
+		t.Errorf("Unexpected confirmed row: %v", confirmed)
+	}
+	if confirmed[10] != "2024-11-15T10:53:34Z" {
+		t.Errorf("Expected RFC3339 block time, got %q", confirmed[10])
+	}
+
+	missed := lines[2]
+	if missed[2] != "" || missed[4] != "missed" || missed[6] != "true" || missed[11] != "" {
+		t.Errorf("Unexpected missed row: %v", missed)
+	}
+}
+
+func TestWriteParquet(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatParquet, testRecords()); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	rows, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Failed to read Parquet: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+	if rows[0].CLHeight != 7458296 || rows[0].FeesWei != "21000000000000" {
+		t.Errorf("Unexpected first row: %+v", rows[0])
+	}
+	if rows[1].MissReason != "outside_scan_window" || !rows[1].EmptyConsensus {
+		t.Errorf("Unexpected second row: %+v", rows[1])
+	}
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, "xlsx", nil); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
@@ -0,0 +1,129 @@
+package lcd
+
+import (
+	"fmt"
+	"strings"
+)
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// DecodeBech32 returns the human readable prefix and data of a bech32
+// address such as a valcons address, so it can be compared with the hex
+// addresses CometBFT reports.
+func DecodeBech32(address string) (string, []byte, error) {
+	if strings.ToLower(address) != address && strings.ToUpper(address) != address {
+		return "", nil, fmt.Errorf("mixed case in bech32 address %q", address)
+	}
+	address = strings.ToLower(address)
+
+	sep := strings.LastIndexByte(address, '1')
+	if sep < 1 || sep+7 > len(address) {
+		return "", nil, fmt.Errorf("invalid bech32 address %q", address)
+	}
+	hrp := address[:sep]
+
+	values := make([]byte, 0, len(address)-sep-1)
+	for _, char := range address[sep+1:] {
+		value := strings.IndexRune(bech32Charset, char)
+		if value < 0 {
+			return "", nil, fmt.Errorf("invalid character %q in bech32 address", char)
+		}
+		values = append(values, byte(value))
+	}
+
+	if bech32Polymod(append(bech32ExpandPrefix(hrp), values...)) != 1 {
+		return "", nil, fmt.Errorf("invalid checksum in bech32 address %q", address)
+	}
+
+	data, err := convertBits(values[:len(values)-6], 5, 8, false)
+	if err != nil {
+		return "", nil, err
+	}
+	return hrp, data, nil
+}
+
+// EncodeBech32 encodes data as a bech32 address with prefix hrp.
+func EncodeBech32(hrp string, data []byte) (string, error) {
+	values, err := convertBits(data, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+
+	polymod := bech32Polymod(append(append(bech32ExpandPrefix(hrp), values...), 0, 0, 0, 0, 0, 0)) ^ 1
+	for i := 0; i < 6; i++ {
+		values = append(values, byte(polymod>>(5*(5-i))&31))
+	}
+
+	var address strings.Builder
+	address.WriteString(hrp)
+	address.WriteByte('1')
+	for _, value := range values {
+		address.WriteByte(bech32Charset[value])
+	}
+	return address.String(), nil
+}
+
+// AccountAddress returns the account address of a validator operator
+// address, which shares its bytes under the prefix without "valoper".
+func AccountAddress(valoper string) (string, error) {
+	hrp, data, err := DecodeBech32(valoper)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasSuffix(hrp, "valoper") {
+		return "", fmt.Errorf("%s is not an operator address", valoper)
+	}
+	return EncodeBech32(strings.TrimSuffix(hrp, "valoper"), data)
+}
+
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, value := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(value)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32ExpandPrefix(hrp string) []byte {
+	expanded := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]&31)
+	}
+	return expanded
+}
+
+// convertBits regroups data from groups of from bits into groups of to bits.
+// Encoding pads the last group; decoding rejects non-zero padding.
+func convertBits(data []byte, from, to uint, pad bool) ([]byte, error) {
+	var acc, bits uint
+	var out []byte
+	maxValue := uint(1)<<to - 1
+
+	for _, value := range data {
+		acc = acc<<from | uint(value)
+		bits += from
+		for bits >= to {
+			bits -= to
+			out = append(out, byte(acc>>bits&maxValue))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			out = append(out, byte(acc<<(to-bits)&maxValue))
+		}
+	} else if bits >= from || (acc<<(to-bits))&maxValue != 0 {
+		return nil, fmt.Errorf("invalid padding in bech32 data")
+	}
+	return out, nil
+}
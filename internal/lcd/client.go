@@ -0,0 +1,123 @@
+package lcd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	httpClient "cosmos-evm-exporter/internal/http"
+)
+
+// Client reads module state from the Cosmos SDK REST (LCD) gateway.
+type Client struct {
+	endpoint string
+	http     *httpClient.Client
+}
+
+func NewClient(endpoint string) *Client {
+	return &Client{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		http:     httpClient.NewClient(),
+	}
+}
+
+// APIError is a failed gRPC-gateway request. Code is the gRPC status code.
+type APIError struct {
+	Path    string
+	Status  int
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("%s returned %d: %s", e.Path, e.Status, e.Message)
+	}
+	return fmt.Sprintf("%s returned %d", e.Path, e.Status)
+}
+
+// IsNotFound reports whether err is a query for something that does not
+// exist. Modules disagree on the status they use for it, so the message is
+// checked too.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.Status == http.StatusNotFound || apiErr.Code == 5 || strings.Contains(apiErr.Message, "not found")
+}
+
+// pagination is the page information of list queries.
+type pagination struct {
+	NextKey string `json:"next_key"`
+}
+
+// get decodes the response to path with query into result.
+func (c *Client) get(ctx context.Context, path string, query url.Values, result interface{}) error {
+	target := c.endpoint + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", target, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.http.DoRequest(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{Path: path, Status: resp.StatusCode}
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		json.Unmarshal(body, apiErr)
+		return apiErr
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", path, err)
+	}
+	return nil
+}
+
+// getPages follows the pagination of a list query and returns the items of
+// every page, found under field.
+func getPages[T any](ctx context.Context, c *Client, path, field string, query url.Values) ([]T, error) {
+	var items []T
+	for {
+		var response map[string]json.RawMessage
+		if err := c.get(ctx, path, query, &response); err != nil {
+			return nil, err
+		}
+
+		if raw, ok := response[field]; ok {
+			var page []T
+			if err := json.Unmarshal(raw, &page); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", field, err)
+			}
+			items = append(items, page...)
+		}
+
+		var next pagination
+		if raw, ok := response["pagination"]; ok {
+			if err := json.Unmarshal(raw, &next); err != nil {
+				return nil, fmt.Errorf("failed to parse pagination: %w", err)
+			}
+		}
+		if next.NextKey == "" {
+			return items, nil
+		}
+		if next.NextKey == query.Get("pagination.key") {
+			return nil, fmt.Errorf("%s pagination did not advance", path)
+		}
+		query.Set("pagination.key", next.NextKey)
+	}
+}
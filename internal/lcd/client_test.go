@@ -0,0 +1,117 @@
+package lcd
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cosmos/staking/v1beta1/validators/evmvaloper1abc" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":5,"message":"validator does not exist","details":[]}`))
+			return
+		}
+		w.Write([]byte(`{"validator":{"operator_address":"evmvaloper1abc","jailed":true,"status":"BOND_STATUS_UNBONDING",
+			"tokens":"1000000","description":{"moniker":"ours"},
+			"commission":{"commission_rates":{"rate":"0.050000000000000000","max_rate":"0.2","max_change_rate":"0.01"}}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL + "/")
+
+	validator, err := client.Validator(context.Background(), "evmvaloper1abc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !validator.Jailed || validator.Status != BondStatusUnbonding || validator.Tokens != "1000000" {
+		t.Errorf("unexpected validator: %+v", validator)
+	}
+	if validator.Commission.CommissionRates.Rate != "0.050000000000000000" {
+		t.Errorf("unexpected commission rate %s", validator.Commission.CommissionRates.Rate)
+	}
+
+	_, err = client.Validator(context.Background(), "evmvaloper1xyz")
+	if err == nil || !strings.Contains(err.Error(), "validator does not exist") {
+		t.Errorf("expected the gateway error message, got %v", err)
+	}
+}
+
+func TestSigningInfosPagination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("pagination.key") {
+		case "":
+			w.Write([]byte(`{"info":[{"address":"a","missed_blocks_counter":"1","jailed_until":"1970-01-01T00:00:00Z"}],
+				"pagination":{"next_key":"AQ==","total":"2"}}`))
+		case "AQ==":
+			w.Write([]byte(`{"info":[{"address":"b","missed_blocks_counter":"2","tombstoned":true,"jailed_until":"9999-12-31T23:59:59Z"}],
+				"pagination":{"next_key":null,"total":"0"}}`))
+		}
+	}))
+	defer server.Close()
+
+	infos, err := NewClient(server.URL).SigningInfos(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(infos) != 2 {
+		t.Fatalf("expected 2 signing infos, got %d", len(infos))
+	}
+	if infos[1].Address != "b" || !infos[1].Tombstoned || infos[1].MissedBlocksCounter != "2" {
+		t.Errorf("unexpected second signing info: %+v", infos[1])
+	}
+}
+
+func TestDecodeBech32(t *testing.T) {
+	hrp, data, err := DecodeBech32("cosmosvalcons1tg4ku83ap38h4zuup50z7wjtt3kharuslgh9sl")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hrp != "cosmosvalcons" {
+		t.Errorf("expected prefix cosmosvalcons, got %s", hrp)
+	}
+	if got := strings.ToUpper(hex.EncodeToString(data)); got != "5A2B6E1E3D0C4F7A8B9C0D1E2F3A4B5C6D7E8F90" {
+		t.Errorf("unexpected data %s", got)
+	}
+
+	invalid := []string{
+		"cosmosvalcons1tg4ku83ap38h4zuup50z7wjtt3kharuslgh9sq", // checksum
+		"cosmosvalcons1tg4ku83ap38h4zuup50z7wjtt3kharuslgh9sb", // character
+		"cosmosvalcons1TG4ku83ap38h4zuup50z7wjtt3kharuslgh9sl", // mixed case
+		"tg4ku83ap38h4zuup50z7wjtt3kharuslgh9sl",               // no separator
+	}
+	for _, address := range invalid {
+		if _, _, err := DecodeBech32(address); err == nil {
+			t.Errorf("expected an error for %s", address)
+		}
+	}
+}
+
+func TestAccountAddress(t *testing.T) {
+	data, _ := hex.DecodeString("5A2B6E1E3D0C4F7A8B9C0D1E2F3A4B5C6D7E8F90")
+
+	encoded, err := EncodeBech32("cosmosvalcons", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if encoded != "cosmosvalcons1tg4ku83ap38h4zuup50z7wjtt3kharuslgh9sl" {
+		t.Errorf("unexpected encoding %s", encoded)
+	}
+
+	valoper, _ := EncodeBech32("evmvaloper", data)
+	account, err := AccountAddress(valoper)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hrp, decoded, _ := DecodeBech32(account); hrp != "evm" || hex.EncodeToString(decoded) != hex.EncodeToString(data) {
+		t.Errorf("unexpected account address %s", account)
+	}
+
+	if _, err := AccountAddress(encoded); err == nil {
+		t.Error("expected an error for a consensus address")
+	}
+}
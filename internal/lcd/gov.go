@@ -0,0 +1,53 @@
+package lcd
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+const ProposalStatusVotingPeriod = "PROPOSAL_STATUS_VOTING_PERIOD"
+
+// Proposal is a governance proposal of the gov v1 API.
+type Proposal struct {
+	ID            string    `json:"id"`
+	Title         string    `json:"title"`
+	Status        string    `json:"status"`
+	VotingEndTime time.Time `json:"voting_end_time"`
+}
+
+// Vote is the vote of one voter on a proposal. Weighted votes have several
+// options.
+type Vote struct {
+	ProposalID string `json:"proposal_id"`
+	Voter      string `json:"voter"`
+	Options    []struct {
+		Option string `json:"option"`
+		Weight string `json:"weight"`
+	} `json:"options"`
+}
+
+// ActiveProposals returns the proposals in their voting period.
+func (c *Client) ActiveProposals(ctx context.Context) ([]Proposal, error) {
+	query := url.Values{
+		"proposal_status":  {ProposalStatusVotingPeriod},
+		"pagination.limit": {"100"},
+	}
+	return getPages[Proposal](ctx, c, "/cosmos/gov/v1/proposals", "proposals", query)
+}
+
+// Vote returns the vote of voter on proposal id, and false if voter has not
+// voted.
+func (c *Client) Vote(ctx context.Context, id, voter string) (Vote, bool, error) {
+	var response struct {
+		Vote Vote `json:"vote"`
+	}
+	path := "/cosmos/gov/v1/proposals/" + url.PathEscape(id) + "/votes/" + url.PathEscape(voter)
+	if err := c.get(ctx, path, nil, &response); err != nil {
+		if IsNotFound(err) {
+			return Vote{}, false, nil
+		}
+		return Vote{}, false, err
+	}
+	return response.Vote, true, nil
+}
@@ -0,0 +1,60 @@
+package lcd
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// Bond statuses of the staking module.
+const (
+	BondStatusBonded    = "BOND_STATUS_BONDED"
+	BondStatusUnbonding = "BOND_STATUS_UNBONDING"
+	BondStatusUnbonded  = "BOND_STATUS_UNBONDED"
+)
+
+// Validator is a validator of the staking module.
+type Validator struct {
+	OperatorAddress string `json:"operator_address"`
+	Jailed          bool   `json:"jailed"`
+	Status          string `json:"status"`
+	Tokens          string `json:"tokens"`
+	Description     struct {
+		Moniker string `json:"moniker"`
+	} `json:"description"`
+	Commission struct {
+		CommissionRates struct {
+			Rate          string `json:"rate"`
+			MaxRate       string `json:"max_rate"`
+			MaxChangeRate string `json:"max_change_rate"`
+		} `json:"commission_rates"`
+	} `json:"commission"`
+}
+
+// SigningInfo is the liveness record the slashing module keeps for a
+// consensus address.
+type SigningInfo struct {
+	Address             string    `json:"address"`
+	StartHeight         string    `json:"start_height"`
+	JailedUntil         time.Time `json:"jailed_until"`
+	Tombstoned          bool      `json:"tombstoned"`
+	MissedBlocksCounter string    `json:"missed_blocks_counter"`
+}
+
+// Validator returns the staking validator with operator address valoper.
+func (c *Client) Validator(ctx context.Context, valoper string) (Validator, error) {
+	var response struct {
+		Validator Validator `json:"validator"`
+	}
+	if err := c.get(ctx, "/cosmos/staking/v1beta1/validators/"+url.PathEscape(valoper), nil, &response); err != nil {
+		return Validator{}, err
+	}
+	return response.Validator, nil
+}
+
+// SigningInfos returns the signing info of every validator, following
+// pagination.
+func (c *Client) SigningInfos(ctx context.Context) ([]SigningInfo, error) {
+	query := url.Values{"pagination.limit": {"500"}}
+	return getPages[SigningInfo](ctx, c, "/cosmos/slashing/v1beta1/signing_infos", "info", query)
+}
@@ -0,0 +1,34 @@
+package lcd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// Plan is a software upgrade scheduled by governance.
+type Plan struct {
+	Name   string `json:"name"`
+	Height string `json:"height"`
+	Info   string `json:"info"`
+}
+
+// HeightInt returns the height at which the chain halts for the upgrade.
+func (p Plan) HeightInt() (int64, error) {
+	height, err := strconv.ParseInt(p.Height, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid upgrade height %q: %w", p.Height, err)
+	}
+	return height, nil
+}
+
+// CurrentPlan returns the pending upgrade plan, or nil if none is scheduled.
+func (c *Client) CurrentPlan(ctx context.Context) (*Plan, error) {
+	var response struct {
+		Plan *Plan `json:"plan"`
+	}
+	if err := c.get(ctx, "/cosmos/upgrade/v1beta1/current_plan", nil, &response); err != nil {
+		return nil, err
+	}
+	return response.Plan, nil
+}
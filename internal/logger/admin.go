@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type levelsResponse struct {
+	Levels map[string]string `json:"levels"`
+}
+
+// LevelHandler serves the component log levels of l.
+//
+// GET returns every component and its level. PUT or POST with the "level"
+// query parameter changes the level of the component named by "component",
+// or of every component when it is omitted.
+func (l *Logger) LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodPost:
+			level, err := ParseLevel(r.URL.Query().Get("level"))
+			if err != nil || r.URL.Query().Get("level") == "" {
+				http.Error(w, "missing or invalid level", http.StatusBadRequest)
+				return
+			}
+
+			component := r.URL.Query().Get("component")
+			if !l.SetComponentLevel(component, level) {
+				http.Error(w, "unknown component: "+component, http.StatusNotFound)
+				return
+			}
+
+			l.WriteJSONLog(LevelInfo, "Log level changed", map[string]interface{}{
+				"component": component,
+				"level":     level.String(),
+			}, nil)
+		default:
+			w.Header().Set("Allow", "GET, PUT, POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		levels := l.Levels()
+		resp := levelsResponse{Levels: make(map[string]string, len(levels))}
+		for name, level := range levels {
+			resp.Levels[name] = level.String()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	})
+}
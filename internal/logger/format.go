@@ -0,0 +1,237 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	FormatJSON     = "json"
+	FormatLogfmt   = "logfmt"
+	FormatConsole  = "console"
+	FormatSlogJSON = "slog-json"
+	FormatSlogText = "slog-text"
+)
+
+// Formatter renders a log entry as a single line, without the trailing newline.
+type Formatter interface {
+	Format(entry *LogEntry) ([]byte, error)
+}
+
+// NewFormatter returns the formatter for a log_format name. An empty name
+// selects JSON.
+func NewFormatter(name string) (Formatter, error) {
+	switch strings.ToLower(name) {
+	case "", FormatJSON:
+		return jsonFormatter{}, nil
+	case FormatLogfmt:
+		return logfmtFormatter{}, nil
+	case FormatConsole:
+		return consoleFormatter{}, nil
+	}
+	return nil, fmt.Errorf("unknown log format %q", name)
+}
+
+// newSlogHandler returns the log/slog handler writing to w for the slog
+// formats, and nil for the formats rendered by a Formatter.
+func newSlogHandler(name string, w io.Writer) slog.Handler {
+	// Levels are filtered by the logger, per component
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug}
+	switch strings.ToLower(name) {
+	case FormatSlogJSON:
+		return slog.NewJSONHandler(w, opts)
+	case FormatSlogText:
+		return slog.NewTextHandler(w, opts)
+	}
+	return nil
+}
+
+// reservedKeys are the entry keys that static fields may not override.
+var reservedKeys = map[string]bool{
+	"time":      true,
+	"level":     true,
+	"component": true,
+	"message":   true,
+	"msg":       true,
+	"data":      true,
+	"error":     true,
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// jsonFormatter writes entries as JSON objects with a fixed key order: time,
+// level, component, message, static fields, data and error.
+type jsonFormatter struct{}
+
+func (jsonFormatter) Format(entry *LogEntry) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	first := true
+	add := func(key string, value interface{}) error {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		buf.WriteString(strconv.Quote(key))
+		buf.WriteByte(':')
+		buf.Write(encoded)
+		return nil
+	}
+
+	if err := add("time", entry.Time); err != nil {
+		return nil, err
+	}
+	if err := add("level", entry.Level); err != nil {
+		return nil, err
+	}
+	if entry.Component != "" {
+		if err := add("component", entry.Component); err != nil {
+			return nil, err
+		}
+	}
+	if err := add("message", entry.Message); err != nil {
+		return nil, err
+	}
+	for _, key := range sortedKeys(entry.Fields) {
+		if reservedKeys[key] {
+			continue
+		}
+		if err := add(key, entry.Fields[key]); err != nil {
+			return nil, err
+		}
+	}
+	if len(entry.Data) > 0 {
+		if err := add("data", entry.Data); err != nil {
+			return nil, err
+		}
+	}
+	if entry.Error != "" {
+		if err := add("error", entry.Error); err != nil {
+			return nil, err
+		}
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// logfmtFormatter writes entries as key=value pairs with data fields flattened
+// into the line.
+type logfmtFormatter struct{}
+
+func (logfmtFormatter) Format(entry *LogEntry) ([]byte, error) {
+	var buf bytes.Buffer
+
+	writePair(&buf, "time", entry.Time)
+	writePair(&buf, "level", entry.Level)
+	if entry.Component != "" {
+		writePair(&buf, "component", entry.Component)
+	}
+	writePair(&buf, "msg", entry.Message)
+	for _, key := range sortedKeys(entry.Fields) {
+		if !reservedKeys[key] {
+			writePair(&buf, key, entry.Fields[key])
+		}
+	}
+	for _, key := range sortedKeys(entry.Data) {
+		writePair(&buf, key, entry.Data[key])
+	}
+	if entry.Error != "" {
+		writePair(&buf, "error", entry.Error)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// consoleFormatter writes human readable lines for interactive use.
+type consoleFormatter struct{}
+
+var levelEmoji = map[string]string{
+	"info":    "ℹ️ ",
+	"success": "✅",
+	"warn":    "⚠️ ",
+	"error":   "❌",
+	"fatal":   "💀",
+}
+
+func (consoleFormatter) Format(entry *LogEntry) ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteString(entry.Time)
+	buf.WriteByte(' ')
+	if emoji, ok := levelEmoji[entry.Level]; ok {
+		buf.WriteString(emoji)
+		buf.WriteByte(' ')
+	}
+	fmt.Fprintf(&buf, "%-7s", strings.ToUpper(entry.Level))
+	if entry.Component != "" {
+		fmt.Fprintf(&buf, " [%s]", entry.Component)
+	}
+	buf.WriteByte(' ')
+	buf.WriteString(entry.Message)
+
+	for _, key := range sortedKeys(entry.Fields) {
+		if !reservedKeys[key] {
+			writePair(&buf, key, entry.Fields[key])
+		}
+	}
+	for _, key := range sortedKeys(entry.Data) {
+		writePair(&buf, key, entry.Data[key])
+	}
+	if entry.Error != "" {
+		writePair(&buf, "error", entry.Error)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writePair(buf *bytes.Buffer, key string, value interface{}) {
+	if buf.Len() > 0 {
+		buf.WriteByte(' ')
+	}
+	buf.WriteString(key)
+	buf.WriteByte('=')
+	buf.WriteString(formatValue(value))
+}
+
+func formatValue(value interface{}) string {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case fmt.Stringer:
+		s = v.String()
+	case map[string]interface{}, []interface{}:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			s = fmt.Sprint(v)
+		} else {
+			s = string(encoded)
+		}
+	default:
+		s = fmt.Sprint(v)
+	}
+
+	if s == "" || strings.ContainsAny(s, " =\"\t\n") {
+		return strconv.Quote(s)
+	}
+	return s
+}
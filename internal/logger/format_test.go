@@ -0,0 +1,170 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testEntry() *LogEntry {
+	return &LogEntry{
+		Time:      "2024-11-15T10:53:34Z",
+		Level:     "success",
+		Component: "processor",
+		Message:   "Found execution block",
+		Fields:    map[string]interface{}{"chain_id": "evm-1", "level": "ignored"},
+		Data: map[string]interface{}{
+			"cl_height": 7458296,
+			"hash":      "0xcf98",
+		},
+		Error: "",
+	}
+}
+
+func TestJSONFormatter(t *testing.T) {
+	line, err := jsonFormatter{}.Format(testEntry())
+	if err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	want := `{"time":"2024-11-15T10:53:34Z","level":"success","component":"processor",` +
+		`"message":"Found execution block","chain_id":"evm-1","data":{"cl_height":7458296,"hash":"0xcf98"}}`
+	if string(line) != want {
+		t.Errorf("Unexpected JSON line:\n got %s\nwant %s", line, want)
+	}
+}
+
+func TestLogfmtFormatter(t *testing.T) {
+	entry := testEntry()
+	entry.Error = "connection refused"
+
+	line, err := logfmtFormatter{}.Format(entry)
+	if err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	want := `time=2024-11-15T10:53:34Z level=success component=processor msg="Found execution block" ` +
+		`chain_id=evm-1 cl_height=7458296 hash=0xcf98 error="connection refused"`
+	if string(line) != want {
+		t.Errorf("Unexpected logfmt line:\n got %s\nwant %s", line, want)
+	}
+}
+
+func TestConsoleFormatter(t *testing.T) {
+	line, err := consoleFormatter{}.Format(testEntry())
+	if err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	for _, part := range []string{"✅", "SUCCESS", "[processor]", "Found execution block", "chain_id=evm-1", "cl_height=7458296"} {
+		if !strings.Contains(string(line), part) {
+			t.Errorf("Expected console line to contain %q, got %s", part, line)
+		}
+	}
+}
+
+func TestNewFormatter(t *testing.T) {
+	for _, name := range []string{"", "json", "logfmt", "console", "JSON"} {
+		if _, err := NewFormatter(name); err != nil {
+			t.Errorf("NewFormatter(%q) returned error: %v", name, err)
+		}
+	}
+	if _, err := NewFormatter("xml"); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
+
+func TestJSONLogHasNoEmoji(t *testing.T) {
+	var buf bytes.Buffer
+	testLogger := &Logger{
+		logger: log.New(&buf, "", 0),
+		config: &Config{},
+		fields: map[string]interface{}{"validator": "ABCD"},
+	}
+
+	testLogger.WriteJSONLog(LevelWarn, "Block not found in range", nil, nil)
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to parse log entry: %v", err)
+	}
+	if entry["message"] != "Block not found in range" {
+		t.Errorf("Expected exact message, got %q", entry["message"])
+	}
+	if entry["validator"] != "ABCD" {
+		t.Errorf("Expected static field validator, got %v", entry["validator"])
+	}
+}
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+
+	root := NewSlogLogger(handler, &Config{
+		Level:  "debug",
+		Fields: map[string]string{"chain_id": "evm-1"},
+	})
+	root.Component("rpc").WriteJSONLog(LevelError, "Failed to fetch block", map[string]interface{}{
+		"height": 42,
+	}, errors.New("timeout"))
+
+	var record struct {
+		Level     string         `json:"level"`
+		Msg       string         `json:"msg"`
+		Component string         `json:"component"`
+		ChainID   string         `json:"chain_id"`
+		Data      map[string]int `json:"data"`
+		Error     string         `json:"error"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Failed to parse slog record %q: %v", buf.String(), err)
+	}
+
+	if record.Level != "ERROR" || record.Msg != "Failed to fetch block" {
+		t.Errorf("Unexpected level/message: %+v", record)
+	}
+	if record.Component != "rpc" || record.ChainID != "evm-1" {
+		t.Errorf("Expected component and static fields, got %+v", record)
+	}
+	if record.Data["height"] != 42 || record.Error != "timeout" {
+		t.Errorf("Expected data and error attributes, got %+v", record)
+	}
+}
+
+func TestStaticFieldsInEveryFormat(t *testing.T) {
+	for format, want := range map[string]string{
+		FormatJSON:     `"chain_id":"evm-1"`,
+		FormatLogfmt:   "chain_id=evm-1",
+		FormatConsole:  "chain_id=evm-1",
+		FormatSlogJSON: `"chain_id":"evm-1"`,
+		FormatSlogText: "chain_id=evm-1",
+	} {
+		t.Run(format, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "exporter.log")
+			root := NewLogger(&Config{
+				EnableFileLog: true,
+				LogFile:       path,
+				Format:        format,
+				Fields:        map[string]string{"chain_id": "evm-1", "instance": "exporter-1"},
+			})
+			root.Component("processor").WriteJSONLog(LevelInfo, "Found execution block", map[string]interface{}{"cl_height": 42}, nil)
+			root.Close()
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read log file: %v", err)
+			}
+			for _, part := range []string{want, "exporter-1", "Found execution block", "processor"} {
+				if !strings.Contains(string(data), part) {
+					t.Errorf("Expected %q in %s line, got %s", part, format, data)
+				}
+			}
+		})
+	}
+}
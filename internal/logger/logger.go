@@ -1,36 +1,80 @@
 package logger
 
 import (
-	"encoding/json"
 	"io"
 	"log"
+	"log/slog"
 	"os"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type LogEntry struct {
-	Time    string                 `json:"time"`
-	Level   string                 `json:"level"`
-	Message string                 `json:"message"`
-	Data    map[string]interface{} `json:"data,omitempty"`
-	Error   string                 `json:"error,omitempty"`
+	Time      string                 `json:"time"`
+	Level     string                 `json:"level"`
+	Component string                 `json:"component,omitempty"`
+	Message   string                 `json:"message"`
+	Fields    map[string]interface{} `json:"-"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+	Error     string                 `json:"error,omitempty"`
 }
 
 type Config struct {
 	EnableFileLog bool   `toml:"enable_file_log"`
 	EnableStdout  bool   `toml:"enable_stdout"`
 	LogFile       string `toml:"log_file"`
+	// Level is the minimum level written by the default logger. Empty means info.
+	Level string `toml:"log_level"`
+	// ComponentLevels overrides Level for named component loggers.
+	ComponentLevels map[string]string `toml:"log_levels"`
+
+	// MaxSizeMB rotates the log file once it would grow past this size.
+	MaxSizeMB int `toml:"log_max_size_mb"`
+	// MaxAgeHours rotates the log file once it has been open this long.
+	MaxAgeHours int `toml:"log_max_age_hours"`
+	// MaxBackups is the number of rotated files to keep. Zero keeps all.
+	MaxBackups int `toml:"log_max_backups"`
+	// Compress gzips rotated files.
+	Compress bool `toml:"log_compress"`
+	// FileMode is the octal permission of created log files, "0644" by default.
+	FileMode string `toml:"log_file_mode"`
+
+	// Format selects the output format: json (default), logfmt, console, or
+	// slog-json and slog-text through the log/slog handlers.
+	Format string `toml:"log_format"`
+	// Fields are static fields such as chain_id added to every entry.
+	Fields map[string]string `toml:"log_fields"`
 }
 
+// DefaultComponent is the name the root logger is reported under.
+const DefaultComponent = "default"
+
 type Logger struct {
-	logger *log.Logger
-	config *Config
+	logger    *log.Logger
+	config    *Config
+	component string
+	level     atomic.Int32
+	registry  *registry
+	file      *rotatingFile
+	formatter Formatter
+	handler   slog.Handler
+	fields    map[string]interface{}
+}
+
+// registry tracks the component loggers derived from a root logger so their
+// levels can be listed and changed at runtime.
+type registry struct {
+	mu      sync.Mutex
+	loggers map[string]*Logger
 }
 
 func NewLogger(config *Config) *Logger {
 	var writer io.Writer
+	var logFile *rotatingFile
 	if config.EnableFileLog {
-		logFile, err := os.OpenFile(config.LogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+		var err error
+		logFile, err = openRotatingFile(config)
 		if err != nil {
 			log.Fatalf("Failed to open log file: %v", err)
 		}
@@ -49,43 +93,216 @@ func NewLogger(config *Config) *Logger {
 		writer = io.Discard
 	}
 
-	return &Logger{
-		logger: log.New(writer, "", 0),
-		config: config,
+	level, err := ParseLevel(config.Level)
+	if err != nil {
+		log.Fatalf("Invalid log level: %v", err)
+	}
+	for name, value := range config.ComponentLevels {
+		if _, err := ParseLevel(value); err != nil {
+			log.Fatalf("Invalid log level for component %s: %v", name, err)
+		}
+	}
+
+	// The slog formats hand entries to a log/slog handler over the same outputs
+	if handler := newSlogHandler(config.Format, writer); handler != nil {
+		l := NewSlogLogger(handler, config)
+		l.file = logFile
+		return l
+	}
+
+	formatter, err := NewFormatter(config.Format)
+	if err != nil {
+		log.Fatalf("Invalid log format: %v", err)
+	}
+
+	l := &Logger{
+		logger:    log.New(writer, "", 0),
+		config:    config,
+		component: DefaultComponent,
+		registry:  &registry{loggers: make(map[string]*Logger)},
+		file:      logFile,
+		formatter: formatter,
+		fields:    staticFields(config.Fields),
+	}
+	l.SetLevel(level)
+	l.registry.loggers[DefaultComponent] = l
+
+	return l
+}
+
+func staticFields(fields map[string]string) map[string]interface{} {
+	if len(fields) == 0 {
+		return nil
+	}
+	converted := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		converted[key] = value
+	}
+	return converted
+}
+
+// Component returns the logger for the named component, creating it on first
+// use. Its level comes from ComponentLevels, falling back to the level of l.
+func (l *Logger) Component(name string) *Logger {
+	if l.registry == nil {
+		l.registry = &registry{loggers: make(map[string]*Logger)}
+	}
+
+	l.registry.mu.Lock()
+	defer l.registry.mu.Unlock()
+
+	if existing, ok := l.registry.loggers[name]; ok {
+		return existing
+	}
+
+	child := &Logger{
+		logger:    l.logger,
+		config:    l.config,
+		component: name,
+		registry:  l.registry,
+		file:      l.file,
+		formatter: l.formatter,
+		handler:   l.handler,
+		fields:    l.fields,
+	}
+
+	level := l.Level()
+	if l.config != nil {
+		if value, ok := l.config.ComponentLevels[name]; ok {
+			if parsed, err := ParseLevel(value); err == nil {
+				level = parsed
+			}
+		}
+	}
+	child.SetLevel(level)
+	l.registry.loggers[name] = child
+
+	return child
+}
+
+// Reopen closes and reopens the log file, if file logging is enabled. It is
+// meant to be called on SIGUSR1 after logrotate has moved the file away.
+func (l *Logger) Reopen() error {
+	if l.file == nil {
+		return nil
+	}
+	return l.file.Reopen()
+}
+
+// Close closes the log file, if file logging is enabled.
+func (l *Logger) Close() error {
+	if l.file == nil {
+		return nil
+	}
+	return l.file.Close()
+}
+
+// Level returns the minimum level currently written by l.
+func (l *Logger) Level() Level {
+	return Level(l.level.Load())
+}
+
+// SetLevel changes the minimum level written by l. It is safe to call while
+// other goroutines are logging.
+func (l *Logger) SetLevel(level Level) {
+	l.level.Store(int32(level))
+}
+
+// Enabled reports whether an entry at the given level would be written.
+func (l *Logger) Enabled(level Level) bool {
+	return level >= l.Level()
+}
+
+// Levels returns the current level of every known component, keyed by name.
+func (l *Logger) Levels() map[string]Level {
+	levels := map[string]Level{}
+	if l.registry == nil {
+		levels[l.name()] = l.Level()
+		return levels
+	}
+
+	l.registry.mu.Lock()
+	defer l.registry.mu.Unlock()
+	for name, component := range l.registry.loggers {
+		levels[name] = component.Level()
 	}
+	return levels
 }
 
-func (l *Logger) WriteJSONLog(level string, message string, data map[string]interface{}, err error) {
+// SetComponentLevel changes the level of a single component logger. An empty
+// name changes every known component.
+func (l *Logger) SetComponentLevel(name string, level Level) bool {
+	if l.registry == nil {
+		if name != "" && name != l.name() {
+			return false
+		}
+		l.SetLevel(level)
+		return true
+	}
+
+	l.registry.mu.Lock()
+	defer l.registry.mu.Unlock()
+
+	if name == "" {
+		for _, component := range l.registry.loggers {
+			component.SetLevel(level)
+		}
+		return true
+	}
+
+	component, ok := l.registry.loggers[name]
+	if !ok {
+		return false
+	}
+	component.SetLevel(level)
+	return true
+}
+
+func (l *Logger) name() string {
+	if l.component == "" {
+		return DefaultComponent
+	}
+	return l.component
+}
+
+// WriteJSONLog writes an entry if level is enabled for l. Despite the name
+// the entry is rendered in the configured format.
+func (l *Logger) WriteJSONLog(level Level, message string, data map[string]interface{}, err error) {
+	if !l.Enabled(level) {
+		return
+	}
+
+	if l.handler != nil {
+		l.writeSlog(level, message, data, err)
+		return
+	}
+
 	entry := LogEntry{
 		Time:    time.Now().UTC().Format(time.RFC3339),
-		Level:   level,
+		Level:   level.String(),
 		Message: message,
+		Fields:  l.fields,
 		Data:    data,
 	}
 
+	if l.component != DefaultComponent {
+		entry.Component = l.component
+	}
+
 	if err != nil {
 		entry.Error = err.Error()
 	}
 
-	// Add emoji prefix based on level
-	switch level {
-	case "info":
-		entry.Message = "ℹ️ " + entry.Message
-	case "success":
-		entry.Message = "✅ " + entry.Message
-	case "warn":
-		entry.Message = "⚠️ " + entry.Message
-	case "error":
-		entry.Message = "❌ " + entry.Message
-	case "fatal":
-		entry.Message = "💀 " + entry.Message
+	formatter := l.formatter
+	if formatter == nil {
+		formatter = jsonFormatter{}
 	}
 
-	jsonBytes, err := json.Marshal(entry)
+	line, err := formatter.Format(&entry)
 	if err != nil {
 		l.logger.Printf("Error marshaling log entry: %v", err)
 		return
 	}
 
-	l.logger.Println(string(jsonBytes))
+	l.logger.Println(string(line))
 }
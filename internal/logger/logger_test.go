@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -30,7 +32,7 @@ func TestWriteJSONLog(t *testing.T) {
 	}
 
 	// Call WriteJSONLog
-	testLogger.WriteJSONLog("info", "Processing Validator Block", testMetadata, nil)
+	testLogger.WriteJSONLog(LevelInfo, "Processing Validator Block", testMetadata, nil)
 
 	// Parse the log output
 	var logEntry struct {
@@ -100,3 +102,115 @@ func TestSetupLogger(t *testing.T) {
 		t.Error("Expected non-nil logger")
 	}
 }
+
+func TestLevelFiltering(t *testing.T) {
+	var buf bytes.Buffer
+
+	testLogger := &Logger{
+		logger: log.New(&buf, "", 0),
+		config: &Config{},
+	}
+	testLogger.SetLevel(LevelWarn)
+
+	testLogger.WriteJSONLog(LevelDebug, "debug entry", nil, nil)
+	testLogger.WriteJSONLog(LevelInfo, "info entry", nil, nil)
+	testLogger.WriteJSONLog(LevelWarn, "warn entry", nil, nil)
+	testLogger.WriteJSONLog(LevelError, "error entry", nil, nil)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 log lines, got %d: %q", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], "warn entry") || !strings.Contains(lines[1], "error entry") {
+		t.Errorf("Unexpected log lines: %q", lines)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Level
+		wantErr bool
+	}{
+		{input: "", want: LevelInfo},
+		{input: "debug", want: LevelDebug},
+		{input: "WARN", want: LevelWarn},
+		{input: "warning", want: LevelWarn},
+		{input: "success", want: LevelSuccess},
+		{input: "verbose", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseLevel(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLevel(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseLevel(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComponentLevels(t *testing.T) {
+	root := NewLogger(&Config{
+		Level:           "warn",
+		ComponentLevels: map[string]string{"processor": "debug"},
+	})
+
+	processor := root.Component("processor")
+	rpc := root.Component("rpc")
+
+	if processor.Level() != LevelDebug {
+		t.Errorf("Expected processor level debug, got %v", processor.Level())
+	}
+	if rpc.Level() != LevelWarn {
+		t.Errorf("Expected rpc level to inherit warn, got %v", rpc.Level())
+	}
+	if root.Component("processor") != processor {
+		t.Error("Expected Component to return the same logger for the same name")
+	}
+
+	if !root.SetComponentLevel("rpc", LevelError) {
+		t.Fatal("Expected rpc component to be known")
+	}
+	if rpc.Level() != LevelError {
+		t.Errorf("Expected rpc level error, got %v", rpc.Level())
+	}
+	if root.SetComponentLevel("unknown", LevelError) {
+		t.Error("Expected unknown component to be rejected")
+	}
+}
+
+func TestLevelHandler(t *testing.T) {
+	root := NewLogger(&Config{})
+	root.Component("decoder")
+	handler := root.LevelHandler()
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/log-level?component=decoder&level=debug", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+
+	var resp levelsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Levels["decoder"] != "debug" {
+		t.Errorf("Expected decoder level debug, got %q", resp.Levels["decoder"])
+	}
+	if resp.Levels[DefaultComponent] != "info" {
+		t.Errorf("Expected default level info, got %q", resp.Levels[DefaultComponent])
+	}
+
+	req = httptest.NewRequest(http.MethodPut, "/admin/log-level?level=loud", nil)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for invalid level, got %d", rec.Code)
+	}
+}
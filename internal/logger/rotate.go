@@ -0,0 +1,257 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFileMode     = 0644
+	backupTimeFormat    = "20060102T150405.000"
+	compressedExtension = ".gz"
+)
+
+// rotatingFile is an io.Writer over a log file that is rotated once it grows
+// past maxSize or is older than maxAge. Rotated files are renamed
+// to "<path>.<timestamp>", optionally gzipped, and pruned down to maxBackups.
+type rotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	mode       os.FileMode
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+	compress   bool
+
+	file      *os.File
+	size      int64
+	startedAt time.Time // Age of the file's contents, kept across reopens
+
+	// cleanup serialises compression and pruning of rotated files, which run
+	// in the background so a rotation doesn't stall logging.
+	cleanup sync.Mutex
+}
+
+// ParseFileMode parses an octal permission string such as "0640". An empty
+// string yields the default mode.
+func ParseFileMode(value string) (os.FileMode, error) {
+	if value == "" {
+		return defaultFileMode, nil
+	}
+	mode, err := strconv.ParseUint(value, 8, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid file mode %q: %w", value, err)
+	}
+	return os.FileMode(mode).Perm(), nil
+}
+
+func openRotatingFile(config *Config) (*rotatingFile, error) {
+	mode, err := ParseFileMode(config.FileMode)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &rotatingFile{
+		path:       config.LogFile,
+		mode:       mode,
+		maxSize:    int64(config.MaxSizeMB) * 1024 * 1024,
+		maxAge:     time.Duration(config.MaxAgeHours) * time.Hour,
+		maxBackups: config.MaxBackups,
+		compress:   config.Compress,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, f.mode)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+	// A file with contents dates from its last write, so restarts and reopens
+	// don't keep pushing its rotation back
+	f.startedAt = time.Now()
+	if f.size > 0 {
+		f.startedAt = info.ModTime()
+	}
+	return nil
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if f.shouldRotate(len(p)) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *rotatingFile) shouldRotate(next int) bool {
+	if f.size == 0 {
+		return false
+	}
+	if f.maxSize > 0 && f.size+int64(next) > f.maxSize {
+		return true
+	}
+	return f.maxAge > 0 && time.Since(f.startedAt) >= f.maxAge
+}
+
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+
+	backup := f.path + "." + time.Now().UTC().Format(backupTimeFormat)
+	if err := os.Rename(f.path, backup); err != nil {
+		return err
+	}
+
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	go f.cleanupBackups(backup)
+	return nil
+}
+
+// Reopen closes and reopens the log file at its configured path, so an
+// external tool such as logrotate can move the file away first.
+func (f *rotatingFile) Reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file != nil {
+		if err := f.file.Close(); err != nil {
+			return err
+		}
+		f.file = nil
+	}
+	return f.open()
+}
+
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *rotatingFile) cleanupBackups(backup string) {
+	f.cleanup.Lock()
+	defer f.cleanup.Unlock()
+
+	if f.compress {
+		if err := compressFile(backup, f.mode); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to compress log file %s: %v\n", backup, err)
+		}
+	}
+
+	if f.maxBackups > 0 {
+		if err := f.pruneBackups(); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to prune log files: %v\n", err)
+		}
+	}
+}
+
+// backups returns the rotated files of f, oldest first.
+func (f *rotatingFile) backups() ([]string, error) {
+	matches, err := filepath.Glob(f.path + ".*")
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := f.path + "."
+	var backups []string
+	for _, match := range matches {
+		stamp := strings.TrimSuffix(strings.TrimPrefix(match, prefix), compressedExtension)
+		if _, err := time.Parse(backupTimeFormat, stamp); err == nil {
+			backups = append(backups, match)
+		}
+	}
+
+	// The timestamp format sorts lexically in time order.
+	sort.Strings(backups)
+	return backups, nil
+}
+
+func (f *rotatingFile) pruneBackups() error {
+	backups, err := f.backups()
+	if err != nil {
+		return err
+	}
+
+	for len(backups) > f.maxBackups {
+		if err := os.Remove(backups[0]); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+func compressFile(path string, mode os.FileMode) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+compressedExtension, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		gz.Close()
+		dst.Close()
+		os.Remove(path + compressedExtension)
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
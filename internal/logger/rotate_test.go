@@ -0,0 +1,165 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotatingFileRotatesBySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exporter.log")
+
+	f, err := openRotatingFile(&Config{LogFile: path, MaxBackups: 2})
+	if err != nil {
+		t.Fatalf("Failed to open log file: %v", err)
+	}
+	defer f.Close()
+	f.maxSize = 64
+
+	line := []byte(strings.Repeat("x", 40) + "\n")
+	for i := 0; i < 5; i++ {
+		if _, err := f.Write(line); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+		// Backup names have millisecond resolution
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	// Pruning runs in the background after each rotation
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		backups, err := f.backups()
+		if err != nil {
+			t.Fatalf("Failed to list backups: %v", err)
+		}
+		if len(backups) == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected 2 backups after pruning, got %d: %v", len(backups), backups)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat log file: %v", err)
+	}
+	if info.Size() != int64(len(line)) {
+		t.Errorf("Expected current log file to hold one line, got %d bytes", info.Size())
+	}
+}
+
+func TestRotatingFileRotatesByAge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exporter.log")
+
+	// A file last written two hours ago is already past its age on reopen
+	if err := os.WriteFile(path, []byte("old\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := openRotatingFile(&Config{LogFile: path, MaxAgeHours: 1})
+	if err != nil {
+		t.Fatalf("Failed to open log file: %v", err)
+	}
+	defer f.Close()
+
+	f.Write([]byte("new\n"))
+	backups, err := f.backups()
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("Expected the old file to be rotated, got %v (%v)", backups, err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "new\n" {
+		t.Errorf("Expected a fresh log file, got %q", data)
+	}
+
+	// The fresh file is younger than the limit
+	f.Write([]byte("newer\n"))
+	if backups, _ := f.backups(); len(backups) != 1 {
+		t.Errorf("Expected no rotation of a fresh file, got %v", backups)
+	}
+}
+
+func TestRotatingFileCompress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exporter.log")
+
+	f, err := openRotatingFile(&Config{LogFile: path, Compress: true})
+	if err != nil {
+		t.Fatalf("Failed to open log file: %v", err)
+	}
+	defer f.Close()
+
+	f.Write([]byte("first\n"))
+	f.mu.Lock()
+	err = f.rotate()
+	f.mu.Unlock()
+	if err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		matches, _ := filepath.Glob(path + ".*" + compressedExtension)
+		if len(matches) == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected one compressed backup, got %v", matches)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRotatingFileReopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "exporter.log")
+
+	f, err := openRotatingFile(&Config{LogFile: path, FileMode: "0600"})
+	if err != nil {
+		t.Fatalf("Failed to open log file: %v", err)
+	}
+	defer f.Close()
+
+	f.Write([]byte("before\n"))
+	if err := os.Rename(path, filepath.Join(dir, "moved.log")); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Reopen(); err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	f.Write([]byte("after\n"))
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read reopened file: %v", err)
+	}
+	if string(data) != "after\n" {
+		t.Errorf("Expected reopened file to contain only new entries, got %q", data)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected mode 0600, got %o", info.Mode().Perm())
+	}
+}
+
+func TestParseFileMode(t *testing.T) {
+	if mode, err := ParseFileMode(""); err != nil || mode != defaultFileMode {
+		t.Errorf("Expected default mode, got %o, %v", mode, err)
+	}
+	if mode, err := ParseFileMode("0640"); err != nil || mode != 0640 {
+		t.Errorf("Expected 0640, got %o, %v", mode, err)
+	}
+	if _, err := ParseFileMode("rw-r--r--"); err == nil {
+		t.Error("Expected error for non-octal mode")
+	}
+}
@@ -0,0 +1,73 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// slogLevels maps exporter levels onto log/slog levels. Success sits between
+// info and warn since slog has no equivalent.
+var slogLevels = map[Level]slog.Level{
+	LevelDebug:   slog.LevelDebug,
+	LevelInfo:    slog.LevelInfo,
+	LevelSuccess: slog.LevelInfo + 2,
+	LevelWarn:    slog.LevelWarn,
+	LevelError:   slog.LevelError,
+	LevelFatal:   slog.LevelError + 4,
+}
+
+// NewSlogLogger returns a Logger whose entries are handed to a log/slog
+// handler instead of being formatted and written by the logger itself. Level
+// filtering, components and static fields work as with NewLogger; output
+// settings in config are ignored.
+func NewSlogLogger(handler slog.Handler, config *Config) *Logger {
+	level, err := ParseLevel(config.Level)
+	if err != nil {
+		level = LevelInfo
+	}
+
+	l := &Logger{
+		config:    config,
+		component: DefaultComponent,
+		registry:  &registry{loggers: make(map[string]*Logger)},
+		handler:   handler,
+		fields:    staticFields(config.Fields),
+	}
+	l.SetLevel(level)
+	l.registry.loggers[DefaultComponent] = l
+
+	return l
+}
+
+func (l *Logger) writeSlog(level Level, message string, data map[string]interface{}, err error) {
+	slogLevel, ok := slogLevels[level]
+	if !ok {
+		slogLevel = slog.LevelInfo
+	}
+
+	ctx := context.Background()
+	if !l.handler.Enabled(ctx, slogLevel) {
+		return
+	}
+
+	record := slog.NewRecord(time.Now().UTC(), slogLevel, message, 0)
+	if l.component != "" && l.component != DefaultComponent {
+		record.AddAttrs(slog.String("component", l.component))
+	}
+	for _, key := range sortedKeys(l.fields) {
+		record.AddAttrs(slog.Any(key, l.fields[key]))
+	}
+	if len(data) > 0 {
+		attrs := make([]any, 0, len(data))
+		for _, key := range sortedKeys(data) {
+			attrs = append(attrs, slog.Any(key, data[key]))
+		}
+		record.AddAttrs(slog.Group("data", attrs...))
+	}
+	if err != nil {
+		record.AddAttrs(slog.String("error", err.Error()))
+	}
+
+	_ = l.handler.Handle(ctx, record)
+}
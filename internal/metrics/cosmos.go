@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// CosmosMetrics describe the Cosmos SDK module state of the configured
+// validators, read from the Cosmos REST API.
+type CosmosMetrics struct {
+	Status         *prometheus.GaugeVec
+	Jailed         *prometheus.GaugeVec
+	Tokens         *prometheus.GaugeVec
+	CommissionRate *prometheus.GaugeVec
+	MissedBlocks   *prometheus.GaugeVec
+	JailedUntil    *prometheus.GaugeVec
+	Tombstoned     *prometheus.GaugeVec
+
+	ActiveProposals prometheus.Gauge
+	VotingTimeLeft  *prometheus.GaugeVec
+	Voted           *prometheus.GaugeVec
+	VoteOverdue     *prometheus.GaugeVec
+
+	UpgradePlan     *prometheus.GaugeVec
+	UpgradeHeight   prometheus.Gauge
+	UpgradeTimeLeft prometheus.Gauge
+	UpgradeHalted   prometheus.Gauge
+
+	Errors *prometheus.CounterVec
+}
+
+// NewCosmosMetrics registers the Cosmos SDK module metrics in registry.
+func NewCosmosMetrics(registry *prometheus.Registry) *CosmosMetrics {
+	return &CosmosMetrics{
+		Status: promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "staking_validator_status",
+			Help: "Bond status of the validator: 1 for the current status, 0 for the others",
+		}, []string{"validator", "status"}),
+		Jailed: promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "staking_validator_jailed",
+			Help: "Whether the validator is jailed",
+		}, []string{"validator"}),
+		Tokens: promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "staking_validator_tokens",
+			Help: "Tokens bonded to the validator in the base denomination",
+		}, []string{"validator"}),
+		CommissionRate: promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "staking_validator_commission_rate",
+			Help: "Commission rate of the validator as a fraction",
+		}, []string{"validator"}),
+		MissedBlocks: promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "slashing_missed_blocks_counter",
+			Help: "Blocks missed in the current slashing window",
+		}, []string{"validator"}),
+		JailedUntil: promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "slashing_jailed_until_timestamp_seconds",
+			Help: "Unix time until which the validator is jailed",
+		}, []string{"validator"}),
+		Tombstoned: promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "slashing_tombstoned",
+			Help: "Whether the validator is tombstoned and can never rejoin",
+		}, []string{"validator"}),
+		ActiveProposals: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "gov_active_proposals",
+			Help: "Number of governance proposals in their voting period",
+		}),
+		VotingTimeLeft: promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "gov_proposal_voting_seconds_left",
+			Help: "Seconds until the voting period of the proposal ends",
+		}, []string{"proposal"}),
+		Voted: promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "gov_validator_voted",
+			Help: "Whether the validator has voted on the proposal",
+		}, []string{"proposal", "validator"}),
+		VoteOverdue: promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "gov_validator_vote_overdue",
+			Help: "Whether the validator has not voted with less than gov_vote_alert_hours left",
+		}, []string{"proposal", "validator"}),
+		UpgradePlan: promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "upgrade_plan_info",
+			Help: "Name of the pending software upgrade, always 1",
+		}, []string{"name"}),
+		UpgradeHeight: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "upgrade_plan_height",
+			Help: "Height at which the chain halts for the pending upgrade, 0 without one",
+		}),
+		UpgradeTimeLeft: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "upgrade_plan_seconds_left",
+			Help: "Estimated seconds until the upgrade height, from the observed block times",
+		}),
+		UpgradeHalted: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "upgrade_halted",
+			Help: "Whether the chain is halted at the upgrade height, while miss and stall alerting is suppressed",
+		}),
+		Errors: promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+			Name: "lcd_request_errors",
+			Help: "Number of failed Cosmos REST API queries by query",
+		}, []string{"query"}),
+	}
+}
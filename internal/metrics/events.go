@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// EventMetrics describe the contract events matched by the configured
+// event watches.
+type EventMetrics struct {
+	Events      *prometheus.CounterVec
+	Values      *prometheus.CounterVec
+	LastBlock   prometheus.Gauge
+	QueryErrors prometheus.Counter
+}
+
+// NewEventMetrics registers the contract event metrics in registry.
+func NewEventMetrics(registry *prometheus.Registry) *EventMetrics {
+	return &EventMetrics{
+		Events: promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+			Name: "contract_events",
+			Help: "Number of contract events matched by each watch",
+		}, []string{"watch", "validator"}),
+		Values: promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+			Name: "contract_event_value",
+			Help: "Sum of the sum_field of the events matched by each watch",
+		}, []string{"watch", "validator", "field"}),
+		LastBlock: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "contract_events_last_block",
+			Help: "Last execution block scanned for contract events",
+		}),
+		QueryErrors: promauto.With(registry).NewCounter(prometheus.CounterOpts{
+			Name: "contract_event_query_errors",
+			Help: "Number of failed eth_getLogs or eth_blockNumber calls",
+		}),
+	}
+}
@@ -9,12 +9,42 @@ type BlockMetrics struct {
 	Registry             *prometheus.Registry
 	TotalProposed        prometheus.Counter
 	ExecutionConfirmed   prometheus.Counter
-	ExecutionMissed      prometheus.Counter
+	ExecutionMissed      *prometheus.CounterVec
 	EmptyConsensusBlocks prometheus.Counter
 	EmptyExecutionBlocks prometheus.Counter
 	Errors               prometheus.Counter
+	SignedBlocks         prometheus.Counter
+	MissedSignatures     prometheus.Counter
+	NilVotes             prometheus.Counter
 	CurrentHeight        prometheus.Gauge
 	ElToClGap            prometheus.Gauge
+	Rewards              prometheus.Counter
+	BlockRewards         prometheus.Histogram
+	FeeRecipientBalance  prometheus.Gauge
+	FeeRecipientOutflows prometheus.Counter
+	FeeRecipientOutflow  prometheus.Counter
+	ConsensusRound       prometheus.Gauge
+	RoundsPerHeight      prometheus.Histogram
+	FailedProposals      *prometheus.CounterVec
+	Evidence             *prometheus.CounterVec
+	ValidatorEvidence    *prometheus.CounterVec
+
+	ProposalsWithoutELBlock       prometheus.Counter
+	ProposalsWithMultipleELBlocks prometheus.Counter
+	SharedELBlocks                prometheus.Counter
+	PayloadMismatches             *prometheus.CounterVec
+
+	BlockBlobs           *prometheus.HistogramVec
+	BlockBlobGasUsed     *prometheus.HistogramVec
+	NetworkBlobBaseFee   prometheus.Gauge
+	NetworkExcessBlobGas prometheus.Gauge
+
+	BuilderBlocks   *prometheus.CounterVec
+	BuilderPayments *prometheus.HistogramVec
+
+	CLTxs            *prometheus.CounterVec
+	UnknownCLTxs     *prometheus.CounterVec
+	CLTxDecodeErrors prometheus.Counter
 }
 
 func NewBlockMetrics() *BlockMetrics {
@@ -30,10 +60,10 @@ func NewBlockMetrics() *BlockMetrics {
 			Name: "validator_execution_blocks_confirmed",
 			Help: "Number of proposed blocks that made it to the execution layer",
 		}),
-		ExecutionMissed: promauto.With(registry).NewCounter(prometheus.CounterOpts{
+		ExecutionMissed: promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
 			Name: "validator_execution_blocks_missed",
-			Help: "Number of proposed blocks that failed to make it to the execution layer",
-		}),
+			Help: "Number of proposed blocks that failed to make it to the execution layer, by reason",
+		}, []string{"reason"}),
 		EmptyConsensusBlocks: promauto.With(registry).NewCounter(prometheus.CounterOpts{
 			Name: "validator_empty_consensus_blocks",
 			Help: "Number of blocks proposed with no transactions on consensus layer",
@@ -46,6 +76,18 @@ func NewBlockMetrics() *BlockMetrics {
 			Name: "validator_block_processing_errors",
 			Help: "Number of errors encountered while processing blocks",
 		}),
+		SignedBlocks: promauto.With(registry).NewCounter(prometheus.CounterOpts{
+			Name: "validator_signed_blocks",
+			Help: "Number of blocks whose commit includes our validator's signature",
+		}),
+		MissedSignatures: promauto.With(registry).NewCounter(prometheus.CounterOpts{
+			Name: "validator_missed_signatures",
+			Help: "Number of blocks committed without our validator's signature",
+		}),
+		NilVotes: promauto.With(registry).NewCounter(prometheus.CounterOpts{
+			Name: "validator_nil_votes",
+			Help: "Number of blocks our validator precommitted nil for, counted as signed",
+		}),
 		CurrentHeight: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
 			Name: "validator_current_block_height",
 			Help: "Current block height being processed",
@@ -54,5 +96,102 @@ func NewBlockMetrics() *BlockMetrics {
 			Name: "validator_el_to_cl_gap",
 			Help: "Gap between execution and consensus layer block heights",
 		}),
+		Rewards: promauto.With(registry).NewCounter(prometheus.CounterOpts{
+			Name: "validator_rewards_wei",
+			Help: "Priority fees earned by our blocks, in wei",
+		}),
+		BlockRewards: promauto.With(registry).NewHistogram(prometheus.HistogramOpts{
+			Name:    "validator_block_reward_wei",
+			Help:    "Priority fees earned per block, in wei",
+			Buckets: prometheus.ExponentialBuckets(1e12, 10, 8),
+		}),
+		FeeRecipientBalance: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "validator_fee_recipient_balance_wei",
+			Help: "Balance of the fee recipient after our last block, in wei",
+		}),
+		FeeRecipientOutflows: promauto.With(registry).NewCounter(prometheus.CounterOpts{
+			Name: "validator_fee_recipient_outflows",
+			Help: "Number of balance decreases of the fee recipient between our blocks",
+		}),
+		FeeRecipientOutflow: promauto.With(registry).NewCounter(prometheus.CounterOpts{
+			Name: "validator_fee_recipient_outflow_wei",
+			Help: "Amount that left the fee recipient between our blocks, in wei",
+		}),
+		ConsensusRound: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "validator_consensus_round",
+			Help: "Consensus round of the height currently being decided",
+		}),
+		RoundsPerHeight: promauto.With(registry).NewHistogram(prometheus.HistogramOpts{
+			Name:    "validator_consensus_rounds_per_height",
+			Help:    "Number of consensus rounds needed to decide a height",
+			Buckets: []float64{1, 2, 3, 4, 5, 10},
+		}),
+		FailedProposals: promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+			Name: "validator_failed_proposal_rounds",
+			Help: "Number of consensus rounds that moved on without committing the scheduled proposer's block",
+		}, []string{"validator"}),
+		Evidence: promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+			Name: "validator_network_evidence",
+			Help: "Number of pieces of misbehaviour evidence committed on the chain by type",
+		}, []string{"type"}),
+		ValidatorEvidence: promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+			Name: "validator_double_sign_evidence",
+			Help: "Number of pieces of evidence against a configured validator by type, any increase is critical",
+		}, []string{"validator", "type"}),
+		ProposalsWithoutELBlock: promauto.With(registry).NewCounter(prometheus.CounterOpts{
+			Name: "validator_proposals_without_el_block",
+			Help: "Number of proposals with no execution block from our coinbase in or around the scan window",
+		}),
+		ProposalsWithMultipleELBlocks: promauto.With(registry).NewCounter(prometheus.CounterOpts{
+			Name: "validator_proposals_with_multiple_el_blocks",
+			Help: "Number of proposals with more than one execution block from our coinbase",
+		}),
+		SharedELBlocks: promauto.With(registry).NewCounter(prometheus.CounterOpts{
+			Name: "validator_el_blocks_shared_by_proposals",
+			Help: "Number of times an execution block already matched to one proposal was matched to another",
+		}),
+		PayloadMismatches: promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+			Name: "validator_el_payload_mismatches",
+			Help: "Number of our proposals whose execution payload disagrees with the EL block at its number, by field",
+		}, []string{"field"}),
+		BlockBlobs: promauto.With(registry).NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "validator_el_block_blobs",
+			Help:    "Blobs per execution block, for our blocks and those of other proposers",
+			Buckets: prometheus.LinearBuckets(0, 1, 10),
+		}, []string{"validator"}),
+		BlockBlobGasUsed: promauto.With(registry).NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "validator_el_block_blob_gas_used",
+			Help:    "Blob gas used per execution block, for our blocks and those of other proposers",
+			Buckets: prometheus.LinearBuckets(0, 131072, 10),
+		}, []string{"validator"}),
+		NetworkBlobBaseFee: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "validator_network_blob_base_fee_wei",
+			Help: "Blob base fee of the next execution block reported by the EL, in wei",
+		}),
+		NetworkExcessBlobGas: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "validator_network_excess_blob_gas",
+			Help: "Excess blob gas of the latest execution block",
+		}),
+		BuilderBlocks: promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+			Name: "validator_builder_blocks",
+			Help: "Number of our execution blocks by builder, local for blocks built by our execution client",
+		}, []string{"validator", "builder"}),
+		BuilderPayments: promauto.With(registry).NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "validator_builder_payment_wei",
+			Help:    "Payments from builders to the fee recipient in the last transaction of our execution blocks, in wei",
+			Buckets: prometheus.ExponentialBuckets(1e15, 10, 8),
+		}, []string{"validator", "builder"}),
+		CLTxs: promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+			Name: "validator_cl_txs_decoded",
+			Help: "Number of consensus layer transactions decoded by type",
+		}, []string{"type"}),
+		UnknownCLTxs: promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+			Name: "validator_cl_txs_unknown_msg_type",
+			Help: "Number of consensus layer transactions with a message type no decoder knows, by message type",
+		}, []string{"msg_type"}),
+		CLTxDecodeErrors: promauto.With(registry).NewCounter(prometheus.CounterOpts{
+			Name: "validator_cl_tx_decode_errors",
+			Help: "Number of consensus layer transactions of a known type that failed to decode",
+		}),
 	}
 }
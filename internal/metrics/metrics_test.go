@@ -32,13 +32,6 @@ func TestNewBlockMetrics(t *testing.T) {
 			help:       "Number of proposed blocks that made it to the execution layer",
 			metricType: "counter",
 		},
-		{
-			name:       "ExecutionMissed",
-			metric:     metrics.ExecutionMissed,
-			metricName: "validator_execution_blocks_missed",
-			help:       "Number of proposed blocks that failed to make it to the execution layer",
-			metricType: "counter",
-		},
 		{
 			name:       "EmptyConsensusBlocks",
 			metric:     metrics.EmptyConsensusBlocks,
@@ -60,6 +53,20 @@ func TestNewBlockMetrics(t *testing.T) {
 			help:       "Number of errors encountered while processing blocks",
 			metricType: "counter",
 		},
+		{
+			name:       "SignedBlocks",
+			metric:     metrics.SignedBlocks,
+			metricName: "validator_signed_blocks",
+			help:       "Number of blocks whose commit includes our validator's signature",
+			metricType: "counter",
+		},
+		{
+			name:       "MissedSignatures",
+			metric:     metrics.MissedSignatures,
+			metricName: "validator_missed_signatures",
+			help:       "Number of blocks committed without our validator's signature",
+			metricType: "counter",
+		},
 		{
 			name:       "CurrentHeight",
 			metric:     metrics.CurrentHeight,
@@ -154,3 +161,19 @@ func TestMetricOperations(t *testing.T) {
 		})
 	}
 }
+
+func TestExecutionMissedReasons(t *testing.T) {
+	metrics := NewBlockMetrics()
+	metrics.ExecutionMissed.WithLabelValues("other_proposer").Inc()
+	metrics.ExecutionMissed.WithLabelValues("el_unreachable").Add(2)
+
+	expected := `
+# HELP validator_execution_blocks_missed Number of proposed blocks that failed to make it to the execution layer, by reason
+# TYPE validator_execution_blocks_missed counter
+validator_execution_blocks_missed{reason="el_unreachable"} 2
+validator_execution_blocks_missed{reason="other_proposer"} 1
+`
+	if err := testutil.CollectAndCompare(metrics.ExecutionMissed, strings.NewReader(expected)); err != nil {
+		t.Errorf("CollectAndCompare() error = %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// NodeMetrics describe the health of the consensus node the exporter reads
+// from.
+type NodeMetrics struct {
+	CatchingUp     prometheus.Gauge
+	LatestBlockAge prometheus.Gauge
+	Info           *prometheus.GaugeVec
+	VotingPower    prometheus.Gauge
+	Peers          *prometheus.GaugeVec
+}
+
+// NewNodeMetrics registers the consensus node metrics in registry.
+func NewNodeMetrics(registry *prometheus.Registry) *NodeMetrics {
+	return &NodeMetrics{
+		CatchingUp: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "consensus_node_catching_up",
+			Help: "Whether the consensus node is catching up (1) or in sync (0)",
+		}),
+		LatestBlockAge: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "consensus_node_latest_block_age_seconds",
+			Help: "Seconds since the latest block known to the consensus node",
+		}),
+		Info: promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "consensus_node_info",
+			Help: "Version and network of the consensus node, always 1",
+		}, []string{"version", "network", "moniker"}),
+		VotingPower: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "consensus_node_voting_power",
+			Help: "Voting power of the consensus node's own validator key",
+		}),
+		Peers: promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "consensus_node_peers",
+			Help: "Number of peers of the consensus node by direction",
+		}, []string{"direction"}),
+	}
+}
+
+// ExecutionNodeMetrics describe the health of the execution client the
+// exporter reads from.
+type ExecutionNodeMetrics struct {
+	Syncing         prometheus.Gauge
+	BlocksBehind    prometheus.Gauge
+	Peers           prometheus.Gauge
+	Info            *prometheus.GaugeVec
+	ChainID         prometheus.Gauge
+	ChainIDMismatch prometheus.Gauge
+	TxPool          *prometheus.GaugeVec
+	ProbeErrors     *prometheus.CounterVec
+}
+
+// NewExecutionNodeMetrics registers the execution client metrics in registry.
+func NewExecutionNodeMetrics(registry *prometheus.Registry) *ExecutionNodeMetrics {
+	return &ExecutionNodeMetrics{
+		Syncing: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "execution_node_syncing",
+			Help: "Whether the execution client is syncing (1) or in sync (0)",
+		}),
+		BlocksBehind: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "execution_node_sync_blocks_behind",
+			Help: "Blocks between the execution client's head and the highest known block while syncing",
+		}),
+		Peers: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "execution_node_peers",
+			Help: "Number of peers of the execution client",
+		}),
+		Info: promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "execution_node_info",
+			Help: "Client version of the execution client, always 1",
+		}, []string{"version"}),
+		ChainID: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "execution_node_chain_id",
+			Help: "Chain ID reported by the execution client",
+		}),
+		ChainIDMismatch: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "execution_node_chain_id_mismatch",
+			Help: "Whether the execution client's chain ID differs from expected_chain_id",
+		}),
+		TxPool: promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "execution_node_txpool_transactions",
+			Help: "Transactions in the execution client's pool by state",
+		}, []string{"state"}),
+		ProbeErrors: promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+			Name: "execution_node_probe_errors",
+			Help: "Number of failed execution client health probes by method",
+		}, []string{"method"}),
+	}
+}
+
+// EngineMetrics describe the Engine API link between the consensus and
+// execution clients.
+type EngineMetrics struct {
+	Up           prometheus.Gauge
+	Latency      prometheus.Gauge
+	Capabilities *prometheus.GaugeVec
+	Syncing      prometheus.Gauge
+}
+
+// NewEngineMetrics registers the Engine API metrics in registry.
+func NewEngineMetrics(registry *prometheus.Registry) *EngineMetrics {
+	return &EngineMetrics{
+		Up: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "engine_api_up",
+			Help: "Whether the last Engine API probe succeeded",
+		}),
+		Latency: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "engine_api_latency_seconds",
+			Help: "Duration of the last engine_exchangeCapabilities call",
+		}),
+		Capabilities: promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "engine_api_capability",
+			Help: "Engine API methods supported by the execution client, always 1",
+		}, []string{"method"}),
+		Syncing: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "engine_api_syncing",
+			Help: "Whether eth_syncing over the Engine API port reports progress",
+		}),
+	}
+}
@@ -0,0 +1,255 @@
+package proposals
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 1000
+)
+
+type queryResponse struct {
+	Total     int      `json:"total"`
+	Offset    int      `json:"offset"`
+	Limit     int      `json:"limit"`
+	Proposals []Record `json:"proposals"`
+}
+
+type signaturesResponse struct {
+	Total      int         `json:"total"`
+	Offset     int         `json:"offset"`
+	Limit      int         `json:"limit"`
+	Signatures []Signature `json:"signatures"`
+}
+
+type attemptsResponse struct {
+	Height   int64     `json:"height"`
+	Attempts []Attempt `json:"attempts"`
+}
+
+type errorsResponse struct {
+	Errors []ErrorEvent `json:"errors"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Handler serves GET /api/v1/proposals from store.
+//
+// Supported query parameters are validator, status, from and to (a consensus
+// height or an RFC3339 time), limit (default 50, max 1000) and offset.
+func Handler(store Store) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+			return
+		}
+
+		filter, err := ParseFilter(r.URL.Query())
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+			return
+		}
+
+		records, total, err := store.Query(filter)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, queryResponse{
+			Total:     total,
+			Offset:    filter.Offset,
+			Limit:     filter.Limit,
+			Proposals: records,
+		})
+	})
+}
+
+// SignaturesHandler serves GET /api/v1/signatures from store. It accepts the
+// same query parameters as Handler except status.
+func SignaturesHandler(store SigningStore) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+			return
+		}
+
+		filter, err := ParseFilter(r.URL.Query())
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+			return
+		}
+
+		signatures, total, err := store.Signatures(filter)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, signaturesResponse{
+			Total:      total,
+			Offset:     filter.Offset,
+			Limit:      filter.Limit,
+			Signatures: signatures,
+		})
+	})
+}
+
+// AttemptsHandler serves GET /api/v1/attempts?height=N from store: every EL
+// block inspected while matching the proposal at consensus height N.
+func AttemptsHandler(store AttemptReader) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+			return
+		}
+
+		height, err := strconv.ParseInt(r.URL.Query().Get("height"), 10, 64)
+		if err != nil || height <= 0 {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "height must be a positive integer"})
+			return
+		}
+
+		attempts, err := store.Attempts(height)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+			return
+		}
+		if attempts == nil {
+			attempts = []Attempt{}
+		}
+
+		writeJSON(w, http.StatusOK, attemptsResponse{Height: height, Attempts: attempts})
+	})
+}
+
+// ErrorsHandler serves GET /api/v1/errors from store, oldest first. The
+// optional from and to parameters are RFC3339 times.
+func ErrorsHandler(store ErrorReader) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+			return
+		}
+
+		from, to, err := ParseTimeRange(r.URL.Query())
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+			return
+		}
+
+		events, err := store.Errors(from, to)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+			return
+		}
+		if events == nil {
+			events = []ErrorEvent{}
+		}
+
+		writeJSON(w, http.StatusOK, errorsResponse{Errors: events})
+	})
+}
+
+// ParseTimeRange reads the optional RFC3339 from and to query parameters.
+func ParseTimeRange(query url.Values) (time.Time, time.Time, error) {
+	var bounds [2]time.Time
+	for i, key := range []string{"from", "to"} {
+		value := query.Get(key)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid %s: expected an RFC3339 time, got %q", key, value)
+		}
+		bounds[i] = t
+	}
+	return bounds[0], bounds[1], nil
+}
+
+// ParseFilter builds a Filter from the proposals API query parameters.
+func ParseFilter(query url.Values) (Filter, error) {
+	filter := Filter{
+		Validator: query.Get("validator"),
+		Limit:     defaultPageSize,
+	}
+
+	switch status := Status(query.Get("status")); status {
+	case "", StatusConfirmed, StatusMissed, StatusError:
+		filter.Status = status
+	default:
+		return filter, fmt.Errorf("invalid status %q", status)
+	}
+
+	if value := query.Get("from"); value != "" {
+		height, t, err := parseBound(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid from: %w", err)
+		}
+		filter.FromHeight, filter.FromTime = height, t
+	}
+
+	if value := query.Get("to"); value != "" {
+		height, t, err := parseBound(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid to: %w", err)
+		}
+		filter.ToHeight, filter.ToTime = height, t
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return filter, fmt.Errorf("invalid limit %q", value)
+		}
+		if limit > maxPageSize {
+			limit = maxPageSize
+		}
+		filter.Limit = limit
+	}
+
+	if value := query.Get("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			return filter, fmt.Errorf("invalid offset %q", value)
+		}
+		filter.Offset = offset
+	}
+
+	return filter, nil
+}
+
+// parseBound accepts either a block height or an RFC3339 timestamp.
+func parseBound(value string) (int64, time.Time, error) {
+	if height, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if height < 0 {
+			return 0, time.Time{}, fmt.Errorf("negative height %d", height)
+		}
+		return height, time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("expected a height or RFC3339 time, got %q", value)
+	}
+	return 0, t, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
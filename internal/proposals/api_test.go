@@ -0,0 +1,169 @@
+package proposals
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestHandler(t *testing.T) {
+	store := NewMemoryStore(100)
+	for height := int64(1); height <= 60; height++ {
+		store.Add(Record{Validator: "val1", CLHeight: height, Status: StatusConfirmed})
+	}
+	store.Add(Record{Validator: "val1", CLHeight: 61, Status: StatusMissed, MissReason: MissOtherProposer})
+
+	handler := Handler(store)
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantTotal  int
+		wantLen    int
+	}{
+		{name: "default page", query: "", wantStatus: http.StatusOK, wantTotal: 61, wantLen: 50},
+		{name: "missed only", query: "status=missed", wantStatus: http.StatusOK, wantTotal: 1, wantLen: 1},
+		{name: "height range", query: "from=10&to=19&limit=5", wantStatus: http.StatusOK, wantTotal: 10, wantLen: 5},
+		{name: "unknown validator", query: "validator=val2", wantStatus: http.StatusOK, wantTotal: 0, wantLen: 0},
+		{name: "invalid status", query: "status=lost", wantStatus: http.StatusBadRequest},
+		{name: "invalid from", query: "from=yesterday", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/proposals?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp queryResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.Total != tt.wantTotal {
+				t.Errorf("Expected total %d, got %d", tt.wantTotal, resp.Total)
+			}
+			if len(resp.Proposals) != tt.wantLen {
+				t.Errorf("Expected %d proposals, got %d", tt.wantLen, len(resp.Proposals))
+			}
+		})
+	}
+}
+
+func TestParseFilterTimeBounds(t *testing.T) {
+	filter, err := ParseFilter(url.Values{
+		"from":  {"2024-11-15T00:00:00Z"},
+		"to":    {"2024-11-16T00:00:00Z"},
+		"limit": {"5000"},
+	})
+	if err != nil {
+		t.Fatalf("ParseFilter failed: %v", err)
+	}
+
+	if !filter.FromTime.Equal(time.Date(2024, 11, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected from time %v", filter.FromTime)
+	}
+	if filter.FromHeight != 0 || filter.ToHeight != 0 {
+		t.Errorf("Expected no height bounds, got %d-%d", filter.FromHeight, filter.ToHeight)
+	}
+	if filter.Limit != maxPageSize {
+		t.Errorf("Expected limit capped at %d, got %d", maxPageSize, filter.Limit)
+	}
+}
+
+// diagnosticsStub serves fixed attempts and errors.
+type diagnosticsStub struct {
+	attempts map[int64][]Attempt
+	errors   []ErrorEvent
+}
+
+func (s diagnosticsStub) Attempts(clHeight int64) ([]Attempt, error) {
+	return s.attempts[clHeight], nil
+}
+
+func (s diagnosticsStub) Errors(from, to time.Time) ([]ErrorEvent, error) {
+	var events []ErrorEvent
+	for _, event := range s.errors {
+		if (from.IsZero() || !event.Time.Before(from)) && (to.IsZero() || !event.Time.After(to)) {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func TestAttemptsHandler(t *testing.T) {
+	handler := AttemptsHandler(diagnosticsStub{attempts: map[int64][]Attempt{
+		100: {{CLHeight: 100, ELHeight: 90, Coinbase: "0xdef"}, {CLHeight: 100, ELHeight: 91, Matched: true}},
+	}})
+
+	for _, tt := range []struct {
+		query      string
+		wantStatus int
+		wantLen    int
+	}{
+		{query: "height=100", wantStatus: http.StatusOK, wantLen: 2},
+		{query: "height=101", wantStatus: http.StatusOK, wantLen: 0},
+		{query: "", wantStatus: http.StatusBadRequest},
+		{query: "height=-1", wantStatus: http.StatusBadRequest},
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/attempts?"+tt.query, nil))
+		if rec.Code != tt.wantStatus {
+			t.Fatalf("%s: expected status %d, got %d", tt.query, tt.wantStatus, rec.Code)
+		}
+		if tt.wantStatus != http.StatusOK {
+			continue
+		}
+		var resp attemptsResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if resp.Attempts == nil || len(resp.Attempts) != tt.wantLen {
+			t.Errorf("%s: expected %d attempts, got %+v", tt.query, tt.wantLen, resp.Attempts)
+		}
+	}
+}
+
+func TestErrorsHandler(t *testing.T) {
+	day := time.Date(2024, 11, 15, 0, 0, 0, 0, time.UTC)
+	handler := ErrorsHandler(diagnosticsStub{errors: []ErrorEvent{
+		{Component: "rpc", Message: "Failed to fetch block", Time: day.Add(time.Hour)},
+		{Component: "processor", Message: "Error processing block", Time: day.Add(30 * time.Hour)},
+	}})
+
+	for _, tt := range []struct {
+		query      string
+		wantStatus int
+		wantLen    int
+	}{
+		{query: "", wantStatus: http.StatusOK, wantLen: 2},
+		{query: "from=2024-11-15T00:00:00Z&to=2024-11-16T00:00:00Z", wantStatus: http.StatusOK, wantLen: 1},
+		{query: "from=100", wantStatus: http.StatusBadRequest},
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/errors?"+tt.query, nil))
+		if rec.Code != tt.wantStatus {
+			t.Fatalf("%q: expected status %d, got %d", tt.query, tt.wantStatus, rec.Code)
+		}
+		if tt.wantStatus != http.StatusOK {
+			continue
+		}
+		var resp errorsResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if len(resp.Errors) != tt.wantLen {
+			t.Errorf("%q: expected %d errors, got %+v", tt.query, tt.wantLen, resp.Errors)
+		}
+	}
+}
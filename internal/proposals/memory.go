@@ -0,0 +1,96 @@
+package proposals
+
+import (
+	"sort"
+	"sync"
+)
+
+const DefaultCapacity = 1000
+
+// MemoryStore is a Store that keeps the most recent records in a fixed-size
+// ring buffer. It also implements SigningStore.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	records  []Record
+	next     int
+	full     bool
+	capacity int
+
+	signatures []Signature
+	sigNext    int
+	sigFull    bool
+}
+
+func NewMemoryStore(capacity int) *MemoryStore {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &MemoryStore{
+		records:  make([]Record, capacity),
+		capacity: capacity,
+	}
+}
+
+func (s *MemoryStore) Add(record Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Reprocessing a height replaces its record instead of adding another
+	size := s.next
+	if s.full {
+		size = s.capacity
+	}
+	for i := 0; i < size; i++ {
+		if s.records[i].CLHeight == record.CLHeight && s.records[i].Validator == record.Validator {
+			s.records[i] = record
+			return nil
+		}
+	}
+
+	s.records[s.next] = record
+	s.next = (s.next + 1) % s.capacity
+	if s.next == 0 {
+		s.full = true
+	}
+	return nil
+}
+
+func (s *MemoryStore) Query(filter Filter) ([]Record, int, error) {
+	s.mu.RLock()
+	matches := make([]Record, 0)
+	for _, record := range s.all() {
+		if filter.Matches(record) {
+			matches = append(matches, record)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].CLHeight > matches[j].CLHeight
+	})
+
+	return Paginate(matches, filter.Offset, filter.Limit), len(matches), nil
+}
+
+// all returns the stored records, oldest first. Callers must hold s.mu.
+func (s *MemoryStore) all() []Record {
+	if !s.full {
+		return s.records[:s.next]
+	}
+	ordered := make([]Record, 0, s.capacity)
+	ordered = append(ordered, s.records[s.next:]...)
+	return append(ordered, s.records[:s.next]...)
+}
+
+// Paginate returns the records in [offset, offset+limit). A zero limit
+// returns everything after offset.
+func Paginate(records []Record, offset, limit int) []Record {
+	if offset >= len(records) {
+		return []Record{}
+	}
+	records = records[offset:]
+	if limit > 0 && limit < len(records) {
+		records = records[:limit]
+	}
+	return records
+}
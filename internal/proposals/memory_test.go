@@ -0,0 +1,121 @@
+package proposals
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreRingBuffer(t *testing.T) {
+	store := NewMemoryStore(3)
+
+	for height := int64(1); height <= 5; height++ {
+		if err := store.Add(Record{Validator: "val1", CLHeight: height, Status: StatusConfirmed}); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+
+	records, total, err := store.Query(Filter{})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if total != 3 {
+		t.Fatalf("Expected 3 records after wrap-around, got %d", total)
+	}
+
+	want := []int64{5, 4, 3}
+	for i, record := range records {
+		if record.CLHeight != want[i] {
+			t.Errorf("Record %d: expected height %d, got %d", i, want[i], record.CLHeight)
+		}
+	}
+}
+
+func TestMemoryStoreReplacesHeight(t *testing.T) {
+	store := NewMemoryStore(10)
+
+	store.Add(Record{Validator: "val1", CLHeight: 100, Status: StatusError})
+	store.Add(Record{Validator: "val1", CLHeight: 100, Status: StatusConfirmed})
+
+	records, total, _ := store.Query(Filter{})
+	if total != 1 {
+		t.Fatalf("Expected 1 record, got %d", total)
+	}
+	if records[0].Status != StatusConfirmed {
+		t.Errorf("Expected replaced record to be confirmed, got %s", records[0].Status)
+	}
+}
+
+func TestMemoryStoreQueryFilter(t *testing.T) {
+	store := NewMemoryStore(100)
+	base := time.Date(2024, 11, 15, 10, 0, 0, 0, time.UTC)
+
+	for i := int64(0); i < 10; i++ {
+		status := StatusConfirmed
+		if i%3 == 0 {
+			status = StatusMissed
+		}
+		validator := "val1"
+		if i%2 == 1 {
+			validator = "val2"
+		}
+		store.Add(Record{
+			Validator: validator,
+			CLHeight:  100 + i,
+			Status:    status,
+			BlockTime: base.Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	tests := []struct {
+		name      string
+		filter    Filter
+		wantTotal int
+		wantFirst int64
+	}{
+		{name: "all", filter: Filter{}, wantTotal: 10, wantFirst: 109},
+		{name: "by validator", filter: Filter{Validator: "val2"}, wantTotal: 5, wantFirst: 109},
+		{name: "by status", filter: Filter{Status: StatusMissed}, wantTotal: 4, wantFirst: 109},
+		{name: "by height", filter: Filter{FromHeight: 102, ToHeight: 104}, wantTotal: 3, wantFirst: 104},
+		{name: "by time", filter: Filter{FromTime: base.Add(8 * time.Minute)}, wantTotal: 2, wantFirst: 109},
+		{name: "paginated", filter: Filter{Offset: 2, Limit: 3}, wantTotal: 10, wantFirst: 107},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, total, err := store.Query(tt.filter)
+			if err != nil {
+				t.Fatalf("Query failed: %v", err)
+			}
+			if total != tt.wantTotal {
+				t.Errorf("Expected total %d, got %d", tt.wantTotal, total)
+			}
+			if len(records) == 0 || records[0].CLHeight != tt.wantFirst {
+				t.Errorf("Expected first height %d, got %+v", tt.wantFirst, records)
+			}
+			if tt.filter.Limit > 0 && len(records) > tt.filter.Limit {
+				t.Errorf("Expected at most %d records, got %d", tt.filter.Limit, len(records))
+			}
+		})
+	}
+}
+
+func TestMemoryStoreSignatures(t *testing.T) {
+	store := NewMemoryStore(3)
+
+	for height := int64(1); height <= 5; height++ {
+		store.AddSignature(Signature{Validator: "val1", Height: height, Signed: height%2 == 0})
+	}
+
+	signatures, total, err := store.Signatures(Filter{FromHeight: 4})
+	if err != nil {
+		t.Fatalf("Signatures failed: %v", err)
+	}
+	if total != 2 || signatures[0].Height != 5 || !signatures[1].Signed {
+		t.Errorf("Expected heights 5 and 4, got %+v", signatures)
+	}
+
+	_, total, _ = store.Signatures(Filter{})
+	if total != 3 {
+		t.Errorf("Expected 3 signatures after wrap-around, got %d", total)
+	}
+}
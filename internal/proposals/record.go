@@ -0,0 +1,168 @@
+package proposals
+
+import "time"
+
+// Status is the outcome of one of our validator's proposals.
+type Status string
+
+const (
+	StatusConfirmed Status = "confirmed"
+	StatusMissed    Status = "missed"
+	StatusError     Status = "error"
+)
+
+// MissReason explains why a proposal has no execution block.
+type MissReason string
+
+const (
+	// MissELUnreachable means some blocks of the scan window could not be
+	// fetched, so the block may have been among them.
+	MissELUnreachable MissReason = "el_unreachable"
+	// MissEmptyConsensus means the consensus block carried no payload.
+	MissEmptyConsensus MissReason = "empty_consensus_block"
+	// MissOutsideScanWindow means our block exists but outside the scanned
+	// range, so the CL/EL gap estimate was off.
+	MissOutsideScanWindow MissReason = "outside_scan_window"
+	// MissOtherProposer means another proposer's block sits at the expected
+	// execution height.
+	MissOtherProposer MissReason = "other_proposer"
+	// MissRoundFailed means our proposal round failed and a later round's
+	// proposer won the height. It is recorded by the consensus round tracker,
+	// since the committed block isn't ours and the processor never scans for it.
+	MissRoundFailed MissReason = "proposal_round_failed"
+	// MissUpgradeHalt means the chain halted for a planned upgrade right
+	// after our proposal. It is recorded but not counted as a miss.
+	MissUpgradeHalt MissReason = "upgrade_halt"
+	MissUnknown     MissReason = "unknown"
+)
+
+// MissReasons lists every miss reason counted in the execution missed
+// metric.
+var MissReasons = []MissReason{
+	MissELUnreachable,
+	MissEmptyConsensus,
+	MissOutsideScanWindow,
+	MissOtherProposer,
+	MissRoundFailed,
+	MissUnknown,
+}
+
+// Record describes what happened to a single consensus layer proposal of a
+// tracked validator and the execution block it was matched to.
+type Record struct {
+	Validator      string     `json:"validator"`
+	CLHeight       int64      `json:"cl_height"`
+	ELHeight       int64      `json:"el_height,omitempty"`
+	Hash           string     `json:"hash,omitempty"`
+	Status         Status     `json:"status"`
+	MissReason     MissReason `json:"miss_reason,omitempty"`
+	EmptyConsensus bool       `json:"empty_consensus"`
+	EmptyExecution bool       `json:"empty_execution"`
+	FeesWei        string     `json:"fees_wei,omitempty"`
+	BalanceWei     string     `json:"balance_wei,omitempty"`
+	ScanStart      int64      `json:"scan_start,omitempty"`
+	ScanEnd        int64      `json:"scan_end,omitempty"`
+	Error          string     `json:"error,omitempty"`
+	BlockTime      time.Time  `json:"block_time"`
+	ObservedAt     time.Time  `json:"observed_at"`
+}
+
+// Missed reports whether r counts as a missed proposal. Proposals left
+// without a block by an upgrade halt are not held against the validator.
+func (r Record) Missed() bool {
+	return r.Status == StatusMissed && r.MissReason != MissUpgradeHalt
+}
+
+// WithError returns a copy of r marked as failed with err.
+func (r Record) WithError(err error) Record {
+	r.Status = StatusError
+	r.Error = err.Error()
+	return r
+}
+
+// Filter selects records in a Query. Zero values match everything.
+type Filter struct {
+	Validator  string
+	Status     Status
+	FromHeight int64
+	ToHeight   int64
+	FromTime   time.Time
+	ToTime     time.Time
+	Offset     int
+	Limit      int
+}
+
+// Matches reports whether r is selected by f, ignoring pagination.
+func (f Filter) Matches(r Record) bool {
+	if f.Validator != "" && r.Validator != f.Validator {
+		return false
+	}
+	if f.Status != "" && r.Status != f.Status {
+		return false
+	}
+	if f.FromHeight > 0 && r.CLHeight < f.FromHeight {
+		return false
+	}
+	if f.ToHeight > 0 && r.CLHeight > f.ToHeight {
+		return false
+	}
+	if !f.FromTime.IsZero() && r.BlockTime.Before(f.FromTime) {
+		return false
+	}
+	if !f.ToTime.IsZero() && r.BlockTime.After(f.ToTime) {
+		return false
+	}
+	return true
+}
+
+// Store keeps proposal records for later inspection.
+type Store interface {
+	// Add stores a record, replacing any record for the same validator and height.
+	Add(record Record) error
+	// Query returns the page of matching records, newest first, along with the
+	// total number of matches.
+	Query(filter Filter) ([]Record, int, error)
+}
+
+// Attempt is a single execution block inspected while looking for the block
+// belonging to a proposal.
+type Attempt struct {
+	Validator string    `json:"validator"`
+	CLHeight  int64     `json:"cl_height"`
+	ELHeight  int64     `json:"el_height"`
+	Coinbase  string    `json:"coinbase,omitempty"`
+	Matched   bool      `json:"matched"`
+	Error     string    `json:"error,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// ErrorEvent is an error encountered while processing blocks.
+type ErrorEvent struct {
+	Height    int64     `json:"height,omitempty"`
+	Component string    `json:"component"`
+	Message   string    `json:"message"`
+	Error     string    `json:"error"`
+	Time      time.Time `json:"time"`
+}
+
+// AttemptRecorder is implemented by stores that keep every EL match attempt.
+type AttemptRecorder interface {
+	AddAttempt(attempt Attempt) error
+}
+
+// ErrorRecorder is implemented by stores that keep processing errors.
+type ErrorRecorder interface {
+	AddError(event ErrorEvent) error
+}
+
+// AttemptReader is implemented by stores that can list the EL match attempts
+// of a consensus height.
+type AttemptReader interface {
+	Attempts(clHeight int64) ([]Attempt, error)
+}
+
+// ErrorReader is implemented by stores that can list the processing errors
+// recorded in [from, to]. A zero to means up to now.
+type ErrorReader interface {
+	Errors(from, to time.Time) ([]ErrorEvent, error)
+}
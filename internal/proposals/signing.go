@@ -0,0 +1,100 @@
+package proposals
+
+import (
+	"sort"
+	"time"
+)
+
+// Signature records whether a tracked validator signed the commit for a
+// consensus height. It is taken from the last commit of the next block.
+type Signature struct {
+	Validator string    `json:"validator"`
+	Height    int64     `json:"height"`
+	Signed    bool      `json:"signed"`
+	Time      time.Time `json:"time"`
+}
+
+// SigningRecorder is implemented by stores that keep commit signatures.
+type SigningRecorder interface {
+	AddSignature(signature Signature) error
+}
+
+// SigningStore is a SigningRecorder that can also be queried. Signatures
+// returns the page of matching signatures, newest first, along with the total
+// number of matches. The Status of the filter is ignored.
+type SigningStore interface {
+	SigningRecorder
+	Signatures(filter Filter) ([]Signature, int, error)
+}
+
+// MatchesSignature reports whether s is selected by f, ignoring the status
+// and pagination.
+func (f Filter) MatchesSignature(s Signature) bool {
+	if f.Validator != "" && s.Validator != f.Validator {
+		return false
+	}
+	if f.FromHeight > 0 && s.Height < f.FromHeight {
+		return false
+	}
+	if f.ToHeight > 0 && s.Height > f.ToHeight {
+		return false
+	}
+	if !f.FromTime.IsZero() && s.Time.Before(f.FromTime) {
+		return false
+	}
+	if !f.ToTime.IsZero() && s.Time.After(f.ToTime) {
+		return false
+	}
+	return true
+}
+
+// AddSignature keeps the signature in a ring buffer of the store's capacity,
+// so only the most recent heights are available.
+func (s *MemoryStore) AddSignature(signature Signature) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.signatures == nil {
+		s.signatures = make([]Signature, s.capacity)
+	}
+	s.signatures[s.sigNext] = signature
+	s.sigNext = (s.sigNext + 1) % s.capacity
+	if s.sigNext == 0 {
+		s.sigFull = true
+	}
+	return nil
+}
+
+func (s *MemoryStore) Signatures(filter Filter) ([]Signature, int, error) {
+	s.mu.RLock()
+	size := s.sigNext
+	if s.sigFull {
+		size = s.capacity
+	}
+	matches := make([]Signature, 0)
+	for _, signature := range s.signatures[:size] {
+		if filter.MatchesSignature(signature) {
+			matches = append(matches, signature)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Height > matches[j].Height
+	})
+
+	return PaginateSignatures(matches, filter.Offset, filter.Limit), len(matches), nil
+}
+
+// PaginateSignatures returns the signatures in [offset, offset+limit). A zero
+// limit returns everything after offset.
+func PaginateSignatures(signatures []Signature, offset, limit int) []Signature {
+	if offset >= len(signatures) {
+		return []Signature{}
+	}
+	signatures = signatures[offset:]
+	if limit > 0 && limit < len(signatures) {
+		signatures = signatures[:limit]
+	}
+	return signatures
+}
@@ -0,0 +1,85 @@
+package proposals
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	GroupByDay       = "day"
+	GroupByValidator = "validator"
+)
+
+// Summary aggregates the proposals sharing a group key.
+type Summary struct {
+	Key            string  `json:"key"`
+	Proposals      int     `json:"proposals"`
+	Confirmed      int     `json:"confirmed"`
+	Missed         int     `json:"missed"`
+	Errors         int     `json:"errors"`
+	EmptyConsensus int     `json:"empty_consensus"`
+	EmptyExecution int     `json:"empty_execution"`
+	UpgradeHalts   int     `json:"upgrade_halts"`
+	SuccessRate    float64 `json:"success_rate"`
+}
+
+// Summarize groups records by day (UTC date of the block time) or by
+// validator and counts their outcomes. Groups are sorted by key.
+func Summarize(records []Record, groupBy string) ([]Summary, error) {
+	var keyOf func(Record) string
+	switch groupBy {
+	case GroupByDay:
+		keyOf = func(r Record) string {
+			t := r.BlockTime
+			if t.IsZero() {
+				t = r.ObservedAt
+			}
+			return t.UTC().Format("2006-01-02")
+		}
+	case GroupByValidator:
+		keyOf = func(r Record) string { return r.Validator }
+	default:
+		return nil, fmt.Errorf("unknown grouping %q", groupBy)
+	}
+
+	groups := map[string]*Summary{}
+	for _, record := range records {
+		key := keyOf(record)
+		summary, ok := groups[key]
+		if !ok {
+			summary = &Summary{Key: key}
+			groups[key] = summary
+		}
+
+		summary.Proposals++
+		switch {
+		case record.Status == StatusConfirmed:
+			summary.Confirmed++
+		case record.Missed():
+			summary.Missed++
+		case record.Status == StatusError:
+			summary.Errors++
+		default:
+			summary.UpgradeHalts++
+		}
+		if record.EmptyConsensus {
+			summary.EmptyConsensus++
+		}
+		if record.EmptyExecution {
+			summary.EmptyExecution++
+		}
+	}
+
+	summaries := make([]Summary, 0, len(groups))
+	for _, summary := range groups {
+		if decided := summary.Confirmed + summary.Missed; decided > 0 {
+			summary.SuccessRate = float64(summary.Confirmed) / float64(decided)
+		}
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Key < summaries[j].Key
+	})
+
+	return summaries, nil
+}
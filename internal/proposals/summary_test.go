@@ -0,0 +1,55 @@
+package proposals
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarize(t *testing.T) {
+	day1 := time.Date(2024, 11, 15, 10, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+
+	records := []Record{
+		{Validator: "val1", CLHeight: 1, Status: StatusConfirmed, BlockTime: day1, EmptyExecution: true},
+		{Validator: "val1", CLHeight: 2, Status: StatusMissed, BlockTime: day1, EmptyConsensus: true},
+		{Validator: "val2", CLHeight: 3, Status: StatusConfirmed, BlockTime: day2},
+		{Validator: "val2", CLHeight: 4, Status: StatusError, BlockTime: day2},
+	}
+
+	byDay, err := Summarize(records, GroupByDay)
+	if err != nil {
+		t.Fatalf("Summarize failed: %v", err)
+	}
+	if len(byDay) != 2 || byDay[0].Key != "2024-11-15" {
+		t.Fatalf("Unexpected day groups: %+v", byDay)
+	}
+	if byDay[0].Proposals != 2 || byDay[0].SuccessRate != 0.5 || byDay[0].EmptyConsensus != 1 {
+		t.Errorf("Unexpected first day summary: %+v", byDay[0])
+	}
+	// Errors don't count against the success rate
+	if byDay[1].Errors != 1 || byDay[1].SuccessRate != 1 {
+		t.Errorf("Unexpected second day summary: %+v", byDay[1])
+	}
+
+	byValidator, err := Summarize(records, GroupByValidator)
+	if err != nil {
+		t.Fatalf("Summarize failed: %v", err)
+	}
+	if len(byValidator) != 2 || byValidator[1].Key != "val2" || byValidator[1].Confirmed != 1 {
+		t.Errorf("Unexpected validator groups: %+v", byValidator)
+	}
+
+	// Proposals left without a block by an upgrade halt are not misses
+	halted := append(records, Record{Validator: "val2", CLHeight: 5, Status: StatusMissed, MissReason: MissUpgradeHalt, BlockTime: day2})
+	byValidator, err = Summarize(halted, GroupByValidator)
+	if err != nil {
+		t.Fatalf("Summarize failed: %v", err)
+	}
+	if got := byValidator[1]; got.Proposals != 3 || got.Missed != 0 || got.UpgradeHalts != 1 || got.SuccessRate != 1 {
+		t.Errorf("Unexpected summary with upgrade halt: %+v", got)
+	}
+
+	if _, err := Summarize(records, "week"); err == nil {
+		t.Error("Expected error for unknown grouping")
+	}
+}
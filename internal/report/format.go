@@ -0,0 +1,154 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+	FormatJSON     = "json"
+)
+
+// Write renders the report in the given format.
+func Write(w io.Writer, format string, report Report) error {
+	switch format {
+	case FormatMarkdown, "md":
+		return WriteMarkdown(w, report)
+	case FormatHTML:
+		return WriteHTML(w, report)
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	default:
+		return fmt.Errorf("unknown report format %q", format)
+	}
+}
+
+func WriteMarkdown(w io.Writer, report Report) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Validator SLA report\n\n")
+	fmt.Fprintf(&b, "Period: %s\n\n", period(report))
+	fmt.Fprintf(&b, "Generated: %s\n", report.GeneratedAt.UTC().Format(time.RFC3339))
+
+	for _, v := range report.Validators {
+		fmt.Fprintf(&b, "\n## %s\n\n", v.Validator)
+		b.WriteString("| Metric | Value |\n|---|---|\n")
+		for _, row := range summaryRows(v) {
+			fmt.Fprintf(&b, "| %s | %s |\n", row[0], row[1])
+		}
+
+		b.WriteString("\n### Incidents\n\n")
+		if len(v.Incidents) == 0 {
+			b.WriteString("None.\n")
+			continue
+		}
+		b.WriteString("| Height | Time | Kind | Detail |\n|---|---|---|---|\n")
+		for _, incident := range v.Incidents {
+			fmt.Fprintf(&b, "| %d | %s | %s | %s |\n",
+				incident.Height, formatTime(incident.Time), incident.Kind,
+				strings.ReplaceAll(incident.Detail, "|", "\\|"))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"summaryRows": summaryRows,
+	"formatTime":  formatTime,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Validator SLA report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+</style>
+</head>
+<body>
+<h1>Validator SLA report</h1>
+<p>Period: {{.Period}}<br>Generated: {{formatTime .Report.GeneratedAt}}</p>
+{{range .Report.Validators}}
+<h2>{{.Validator}}</h2>
+<table>
+<tr><th>Metric</th><th>Value</th></tr>
+{{range summaryRows .}}<tr><td>{{index . 0}}</td><td>{{index . 1}}</td></tr>
+{{end}}</table>
+<h3>Incidents</h3>
+{{if .Incidents}}<table>
+<tr><th>Height</th><th>Time</th><th>Kind</th><th>Detail</th></tr>
+{{range .Incidents}}<tr><td>{{.Height}}</td><td>{{formatTime .Time}}</td><td>{{.Kind}}</td><td>{{.Detail}}</td></tr>
+{{end}}</table>
+{{else}}<p>None.</p>
+{{end}}{{end}}</body>
+</html>
+`))
+
+func WriteHTML(w io.Writer, report Report) error {
+	return htmlTemplate.Execute(w, struct {
+		Period string
+		Report Report
+	}{period(report), report})
+}
+
+// summaryRows lists the figures shown for a validator as label/value pairs.
+func summaryRows(v Validator) [][2]string {
+	rows := [][2]string{
+		{"Proposals", fmt.Sprint(v.Proposals)},
+		{"Confirmed", fmt.Sprint(v.Confirmed)},
+		{"Missed", fmt.Sprint(v.Missed)},
+		{"Errors", fmt.Sprint(v.Errors)},
+		{"Proposal success rate", percent(v.ProposalSuccessRate)},
+		{"Execution inclusion rate", percent(v.InclusionRate)},
+		{"Empty block share", percent(v.EmptyBlockShare)},
+		{"Signed blocks", fmt.Sprint(v.SignedBlocks)},
+		{"Missed signatures", fmt.Sprint(v.MissedSignatures)},
+		{"Signing uptime", percent(v.SigningUptime)},
+	}
+	longest := "None"
+	if v.LongestOutage != nil {
+		longest = outageDetail(*v.LongestOutage)
+	}
+	return append(rows, [2]string{"Longest outage", longest})
+}
+
+func outageDetail(o Outage) string {
+	detail := fmt.Sprintf("%d blocks (%d-%d)", o.Blocks, o.FromHeight, o.ToHeight)
+	if !o.Start.IsZero() && !o.End.IsZero() {
+		detail += fmt.Sprintf(", %s", o.End.Sub(o.Start).Round(time.Second))
+	}
+	return detail
+}
+
+func period(report Report) string {
+	from, to := report.From, report.To
+	if from == "" {
+		from = "start"
+	}
+	if to == "" {
+		to = "now"
+	}
+	return from + " to " + to
+}
+
+func percent(rate float64) string {
+	return fmt.Sprintf("%.2f%%", rate*100)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,220 @@
+package report
+
+import (
+	"sort"
+	"time"
+
+	"cosmos-evm-exporter/internal/proposals"
+)
+
+const (
+	IncidentMissedProposal = "missed_proposal"
+	IncidentProposalError  = "proposal_error"
+	IncidentSigningOutage  = "signing_outage"
+)
+
+// Report is the SLA summary of every validator seen in a period.
+type Report struct {
+	From        string      `json:"from,omitempty"`
+	To          string      `json:"to,omitempty"`
+	GeneratedAt time.Time   `json:"generated_at"`
+	Validators  []Validator `json:"validators"`
+}
+
+// Validator holds the SLA figures of one validator.
+//
+// ProposalSuccessRate is confirmed over all recorded proposals but those
+// halted by an upgrade, so errors count against it. InclusionRate is confirmed over confirmed plus missed,
+// which is what the execution block metrics measure.
+type Validator struct {
+	Validator           string     `json:"validator"`
+	Proposals           int        `json:"proposals"`
+	Confirmed           int        `json:"confirmed"`
+	Missed              int        `json:"missed"`
+	Errors              int        `json:"errors"`
+	EmptyConsensus      int        `json:"empty_consensus"`
+	EmptyExecution      int        `json:"empty_execution"`
+	UpgradeHalts        int        `json:"upgrade_halts"`
+	ProposalSuccessRate float64    `json:"proposal_success_rate"`
+	InclusionRate       float64    `json:"inclusion_rate"`
+	EmptyBlockShare     float64    `json:"empty_block_share"`
+	SignedBlocks        int        `json:"signed_blocks"`
+	MissedSignatures    int        `json:"missed_signatures"`
+	SigningUptime       float64    `json:"signing_uptime"`
+	LongestOutage       *Outage    `json:"longest_outage,omitempty"`
+	Incidents           []Incident `json:"incidents"`
+}
+
+// Outage is a run of consecutive heights without our signature.
+type Outage struct {
+	FromHeight int64     `json:"from_height"`
+	ToHeight   int64     `json:"to_height"`
+	Blocks     int       `json:"blocks"`
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+}
+
+// Incident is a missed or failed proposal, or a signing outage.
+type Incident struct {
+	Kind   string    `json:"kind"`
+	Height int64     `json:"height"`
+	Time   time.Time `json:"time"`
+	Detail string    `json:"detail,omitempty"`
+}
+
+// Options tune report generation.
+type Options struct {
+	// From and To describe the period and are copied into the report.
+	From, To string
+	// MinOutage is the shortest signing outage, in blocks, listed as an
+	// incident. The longest outage is reported regardless.
+	MinOutage int
+	Now       time.Time
+}
+
+// Build computes the report for the given proposal records and signatures.
+// Proposal counts come from proposals.Summarize so they agree with the query
+// command.
+func Build(records []proposals.Record, signatures []proposals.Signature, opts Options) Report {
+	if opts.MinOutage <= 0 {
+		opts.MinOutage = 1
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now().UTC()
+	}
+
+	validators := map[string]*Validator{}
+	get := func(name string) *Validator {
+		v, ok := validators[name]
+		if !ok {
+			v = &Validator{Validator: name, Incidents: []Incident{}}
+			validators[name] = v
+		}
+		return v
+	}
+
+	summaries, _ := proposals.Summarize(records, proposals.GroupByValidator)
+	for _, s := range summaries {
+		v := get(s.Key)
+		v.Proposals = s.Proposals
+		v.Confirmed = s.Confirmed
+		v.Missed = s.Missed
+		v.Errors = s.Errors
+		v.EmptyConsensus = s.EmptyConsensus
+		v.EmptyExecution = s.EmptyExecution
+		v.UpgradeHalts = s.UpgradeHalts
+		v.InclusionRate = s.SuccessRate
+	}
+
+	empty := map[string]int{}
+	for _, record := range sortedRecords(records) {
+		v := get(record.Validator)
+		if record.EmptyConsensus || record.EmptyExecution {
+			empty[record.Validator]++
+		}
+		switch {
+		case record.Missed():
+			v.Incidents = append(v.Incidents, Incident{
+				Kind:   IncidentMissedProposal,
+				Height: record.CLHeight,
+				Time:   record.BlockTime,
+				Detail: string(record.MissReason),
+			})
+		case record.Status == proposals.StatusError:
+			v.Incidents = append(v.Incidents, Incident{
+				Kind:   IncidentProposalError,
+				Height: record.CLHeight,
+				Time:   record.BlockTime,
+				Detail: record.Error,
+			})
+		}
+	}
+
+	byValidator := map[string][]proposals.Signature{}
+	for _, signature := range signatures {
+		byValidator[signature.Validator] = append(byValidator[signature.Validator], signature)
+	}
+	for name, sigs := range byValidator {
+		v := get(name)
+		for _, outage := range signingOutages(v, sigs) {
+			if v.LongestOutage == nil || outage.Blocks > v.LongestOutage.Blocks {
+				o := outage
+				v.LongestOutage = &o
+			}
+			if outage.Blocks >= opts.MinOutage {
+				v.Incidents = append(v.Incidents, Incident{
+					Kind:   IncidentSigningOutage,
+					Height: outage.FromHeight,
+					Time:   outage.Start,
+					Detail: outageDetail(outage),
+				})
+			}
+		}
+	}
+
+	report := Report{From: opts.From, To: opts.To, GeneratedAt: opts.Now}
+	for _, v := range validators {
+		if counted := v.Proposals - v.UpgradeHalts; counted > 0 {
+			v.ProposalSuccessRate = float64(v.Confirmed) / float64(counted)
+		}
+		if v.Proposals > 0 {
+			v.EmptyBlockShare = float64(empty[v.Validator]) / float64(v.Proposals)
+		}
+		if total := v.SignedBlocks + v.MissedSignatures; total > 0 {
+			v.SigningUptime = float64(v.SignedBlocks) / float64(total)
+		}
+		sort.SliceStable(v.Incidents, func(i, j int) bool {
+			return v.Incidents[i].Height < v.Incidents[j].Height
+		})
+		report.Validators = append(report.Validators, *v)
+	}
+	sort.Slice(report.Validators, func(i, j int) bool {
+		return report.Validators[i].Validator < report.Validators[j].Validator
+	})
+
+	return report
+}
+
+// signingOutages counts the signatures of one validator into v and returns
+// its runs of consecutive missed heights. A height without a signature entry
+// ends a run.
+func signingOutages(v *Validator, signatures []proposals.Signature) []Outage {
+	sort.Slice(signatures, func(i, j int) bool {
+		return signatures[i].Height < signatures[j].Height
+	})
+
+	var outages []Outage
+	var current *Outage
+	for _, sig := range signatures {
+		if sig.Signed {
+			v.SignedBlocks++
+			current = nil
+			continue
+		}
+
+		v.MissedSignatures++
+		if current != nil && sig.Height == current.ToHeight+1 {
+			current.ToHeight = sig.Height
+			current.End = sig.Time
+			current.Blocks++
+			continue
+		}
+		outages = append(outages, Outage{
+			FromHeight: sig.Height,
+			ToHeight:   sig.Height,
+			Blocks:     1,
+			Start:      sig.Time,
+			End:        sig.Time,
+		})
+		current = &outages[len(outages)-1]
+	}
+	return outages
+}
+
+func sortedRecords(records []proposals.Record) []proposals.Record {
+	sorted := append([]proposals.Record(nil), records...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CLHeight < sorted[j].CLHeight
+	})
+	return sorted
+}
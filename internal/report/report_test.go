@@ -0,0 +1,141 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"cosmos-evm-exporter/internal/proposals"
+)
+
+func testData() ([]proposals.Record, []proposals.Signature) {
+	base := time.Date(2024, 11, 15, 10, 0, 0, 0, time.UTC)
+	records := []proposals.Record{
+		{Validator: "val1", CLHeight: 100, Status: proposals.StatusConfirmed, BlockTime: base},
+		{Validator: "val1", CLHeight: 110, Status: proposals.StatusConfirmed, EmptyConsensus: true, BlockTime: base.Add(time.Minute)},
+		{Validator: "val1", CLHeight: 120, Status: proposals.StatusMissed, MissReason: proposals.MissOtherProposer, BlockTime: base.Add(2 * time.Minute)},
+		{Validator: "val1", CLHeight: 130, Status: proposals.StatusError, Error: "rpc timeout", BlockTime: base.Add(3 * time.Minute)},
+	}
+
+	var signatures []proposals.Signature
+	for height := int64(100); height < 110; height++ {
+		signatures = append(signatures, proposals.Signature{
+			Validator: "val1",
+			Height:    height,
+			// Miss 102-104 and 107
+			Signed: !(height >= 102 && height <= 104) && height != 107,
+			Time:   base.Add(time.Duration(height-100) * 6 * time.Second),
+		})
+	}
+	return records, signatures
+}
+
+func TestBuild(t *testing.T) {
+	records, signatures := testData()
+	report := Build(records, signatures, Options{MinOutage: 2})
+
+	if len(report.Validators) != 1 {
+		t.Fatalf("Expected 1 validator, got %d", len(report.Validators))
+	}
+	v := report.Validators[0]
+
+	if v.Proposals != 4 || v.Confirmed != 2 || v.Missed != 1 || v.Errors != 1 {
+		t.Errorf("Unexpected proposal counts: %+v", v)
+	}
+	if v.ProposalSuccessRate != 0.5 {
+		t.Errorf("Expected proposal success rate 0.5, got %v", v.ProposalSuccessRate)
+	}
+	if v.InclusionRate != 2.0/3.0 {
+		t.Errorf("Expected inclusion rate 2/3, got %v", v.InclusionRate)
+	}
+	if v.EmptyBlockShare != 0.25 {
+		t.Errorf("Expected empty block share 0.25, got %v", v.EmptyBlockShare)
+	}
+	if v.SignedBlocks != 6 || v.MissedSignatures != 4 || v.SigningUptime != 0.6 {
+		t.Errorf("Unexpected signing figures: signed=%d missed=%d uptime=%v", v.SignedBlocks, v.MissedSignatures, v.SigningUptime)
+	}
+	if v.LongestOutage == nil || v.LongestOutage.FromHeight != 102 || v.LongestOutage.Blocks != 3 {
+		t.Errorf("Expected longest outage 102-104, got %+v", v.LongestOutage)
+	}
+
+	// The single missed signature at 107 is below MinOutage
+	var kinds []string
+	for _, incident := range v.Incidents {
+		kinds = append(kinds, incident.Kind)
+	}
+	want := []string{IncidentSigningOutage, IncidentMissedProposal, IncidentProposalError}
+	if strings.Join(kinds, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected incidents %v, got %v", want, kinds)
+	}
+}
+
+func TestBuildUpgradeHalt(t *testing.T) {
+	records, signatures := testData()
+	records = append(records, proposals.Record{
+		Validator:  "val1",
+		CLHeight:   140,
+		Status:     proposals.StatusMissed,
+		MissReason: proposals.MissUpgradeHalt,
+		BlockTime:  records[3].BlockTime.Add(time.Minute),
+	})
+	v := Build(records, signatures, Options{MinOutage: 2}).Validators[0]
+
+	if v.Proposals != 5 || v.Missed != 1 || v.UpgradeHalts != 1 {
+		t.Errorf("Unexpected proposal counts: %+v", v)
+	}
+	if v.ProposalSuccessRate != 0.5 || v.InclusionRate != 2.0/3.0 {
+		t.Errorf("Expected rates unchanged by the halt, got %v and %v", v.ProposalSuccessRate, v.InclusionRate)
+	}
+	for _, incident := range v.Incidents {
+		if incident.Height == 140 {
+			t.Errorf("Expected no incident for the upgrade halt, got %+v", incident)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	records, signatures := testData()
+	report := Build(records, signatures, Options{From: "100", To: "130"})
+
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{FormatMarkdown, []string{"## val1", "| Signing uptime | 60.00% |", "| 120 |", "100 to 130"}},
+		{FormatHTML, []string{"<h2>val1</h2>", "<td>Signing uptime</td><td>60.00%</td>", "rpc timeout"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, tt.format, report); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("Expected output to contain %q, got:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+
+	t.Run(FormatJSON, func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := Write(&buf, FormatJSON, report); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		var decoded Report
+		if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("Failed to decode JSON report: %v", err)
+		}
+		if decoded.Validators[0].MissedSignatures != 4 {
+			t.Errorf("Expected 4 missed signatures, got %d", decoded.Validators[0].MissedSignatures)
+		}
+	})
+
+	if err := Write(&bytes.Buffer{}, "pdf", report); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
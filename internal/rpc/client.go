@@ -2,10 +2,15 @@ package rpc
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 type Client struct {
@@ -26,6 +31,89 @@ func (c *Client) BlockByNumber(ctx context.Context, number *big.Int) (*types.Blo
 	return c.ethClient.BlockByNumber(ctx, number)
 }
 
+// HeaderByNumber returns the header of a block, without its transactions.
+func (c *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return c.ethClient.HeaderByNumber(ctx, number)
+}
+
+// BlockReceipts returns the receipts of every transaction in the block.
+func (c *Client) BlockReceipts(ctx context.Context, number *big.Int) ([]*types.Receipt, error) {
+	return c.ethClient.BlockReceipts(ctx, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(number.Int64())))
+}
+
+// BalanceAt returns the balance of account at block number.
+func (c *Client) BalanceAt(ctx context.Context, account common.Address, number *big.Int) (*big.Int, error) {
+	return c.ethClient.BalanceAt(ctx, account, number)
+}
+
+// SyncProgress returns the sync status of the node, or nil when it is not
+// syncing.
+func (c *Client) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	return c.ethClient.SyncProgress(ctx)
+}
+
+func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	return c.ethClient.ChainID(ctx)
+}
+
+// PeerCount returns the number of peers reported by net_peerCount.
+func (c *Client) PeerCount(ctx context.Context) (uint64, error) {
+	var count hexutil.Uint64
+	if err := c.ethClient.Client().CallContext(ctx, &count, "net_peerCount"); err != nil {
+		return 0, fmt.Errorf("net_peerCount failed: %w", err)
+	}
+	return uint64(count), nil
+}
+
+// ClientVersion returns the node's web3_clientVersion string.
+func (c *Client) ClientVersion(ctx context.Context) (string, error) {
+	var version string
+	if err := c.ethClient.Client().CallContext(ctx, &version, "web3_clientVersion"); err != nil {
+		return "", fmt.Errorf("web3_clientVersion failed: %w", err)
+	}
+	return version, nil
+}
+
+// TxPoolStatus is the number of pending and queued transactions in the
+// node's transaction pool.
+type TxPoolStatus struct {
+	Pending uint64
+	Queued  uint64
+}
+
+// TxPoolStatus calls txpool_status, which some clients only expose when the
+// txpool namespace is enabled.
+func (c *Client) TxPoolStatus(ctx context.Context) (TxPoolStatus, error) {
+	var status struct {
+		Pending hexutil.Uint64 `json:"pending"`
+		Queued  hexutil.Uint64 `json:"queued"`
+	}
+	if err := c.ethClient.Client().CallContext(ctx, &status, "txpool_status"); err != nil {
+		return TxPoolStatus{}, fmt.Errorf("txpool_status failed: %w", err)
+	}
+	return TxPoolStatus{Pending: uint64(status.Pending), Queued: uint64(status.Queued)}, nil
+}
+
+// BlobBaseFee calls eth_blobBaseFee, the blob base fee of the next block
+// under the fork rules the node applies.
+func (c *Client) BlobBaseFee(ctx context.Context) (*big.Int, error) {
+	var fee hexutil.Big
+	if err := c.ethClient.Client().CallContext(ctx, &fee, "eth_blobBaseFee"); err != nil {
+		return nil, fmt.Errorf("eth_blobBaseFee failed: %w", err)
+	}
+	return (*big.Int)(&fee), nil
+}
+
+// BlockNumber returns the number of the latest block.
+func (c *Client) BlockNumber(ctx context.Context) (uint64, error) {
+	return c.ethClient.BlockNumber(ctx)
+}
+
+// FilterLogs returns the logs matching query.
+func (c *Client) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	return c.ethClient.FilterLogs(ctx, query)
+}
+
 // Close releases any resources used by the client
 func (c *Client) Close() {
 	if c.ethClient != nil {
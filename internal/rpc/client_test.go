@@ -130,3 +130,55 @@ func TestBlockByNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestHealthMethods(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		results := map[string]interface{}{
+			"net_peerCount":      "0x19",
+			"web3_clientVersion": "Geth/v1.14.11-stable",
+			"txpool_status":      map[string]string{"pending": "0xc", "queued": "0x3"},
+			"eth_chainId":        "0x138d4",
+			"eth_syncing":        false,
+			"eth_blobBaseFee":    "0x3b9aca00",
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  results[req.Method],
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	if peers, err := client.PeerCount(ctx); err != nil || peers != 25 {
+		t.Errorf("PeerCount() = %d, %v", peers, err)
+	}
+	if version, err := client.ClientVersion(ctx); err != nil || version != "Geth/v1.14.11-stable" {
+		t.Errorf("ClientVersion() = %q, %v", version, err)
+	}
+	if status, err := client.TxPoolStatus(ctx); err != nil || status.Pending != 12 || status.Queued != 3 {
+		t.Errorf("TxPoolStatus() = %+v, %v", status, err)
+	}
+	if chainID, err := client.ChainID(ctx); err != nil || chainID.Int64() != 80084 {
+		t.Errorf("ChainID() = %v, %v", chainID, err)
+	}
+	if progress, err := client.SyncProgress(ctx); err != nil || progress != nil {
+		t.Errorf("SyncProgress() = %+v, %v", progress, err)
+	}
+	if fee, err := client.BlobBaseFee(ctx); err != nil || fee.Int64() != 1e9 {
+		t.Errorf("BlobBaseFee() = %v, %v", fee, err)
+	}
+}
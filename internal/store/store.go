@@ -0,0 +1,429 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"cosmos-evm-exporter/internal/logger"
+	"cosmos-evm-exporter/internal/proposals"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	proposalsBucket = []byte("proposals")
+	attemptsBucket  = []byte("attempts")
+	errorsBucket    = []byte("errors")
+	signingBucket   = []byte("signing")
+)
+
+// ErrLocked is returned by Open when another process holds the database.
+var ErrLocked = errors.New("store is locked by another process")
+
+// Store persists proposal records, EL match attempts and processing errors in
+// an embedded bbolt database. It implements proposals.Store,
+// proposals.AttemptRecorder, proposals.ErrorRecorder and
+// proposals.SigningStore.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens or creates the database at path. bbolt locks the file, so a
+// read-only open fails after a short timeout while an exporter is running.
+func Open(path string, readOnly bool) (*Store, error) {
+	if readOnly {
+		if _, err := os.Stat(path); err != nil {
+			return nil, fmt.Errorf("failed to open store: %w", err)
+		}
+	}
+
+	db, err := bolt.Open(path, 0600, &bolt.Options{
+		Timeout:  2 * time.Second,
+		ReadOnly: readOnly,
+	})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return nil, fmt.Errorf("failed to open store %s: %w", path, ErrLocked)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store %s: %w", path, err)
+	}
+
+	if !readOnly {
+		err = db.Update(func(tx *bolt.Tx) error {
+			for _, name := range [][]byte{proposalsBucket, attemptsBucket, errorsBucket, signingBucket} {
+				if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to create buckets: %w", err)
+		}
+	}
+
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// heightKey orders entries by height, then by the remaining key parts.
+func heightKey(height int64, suffix ...[]byte) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(height))
+	for _, part := range suffix {
+		key = append(key, part...)
+	}
+	return key
+}
+
+func timeKey(t time.Time, seq uint64) []byte {
+	key := make([]byte, 16)
+	binary.BigEndian.PutUint64(key, uint64(t.UnixNano()))
+	binary.BigEndian.PutUint64(key[8:], seq)
+	return key
+}
+
+func put(tx *bolt.Tx, bucket, key []byte, value interface{}) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	b := tx.Bucket(bucket)
+	if b == nil {
+		return fmt.Errorf("bucket %s not found", bucket)
+	}
+	return b.Put(key, encoded)
+}
+
+func (s *Store) Add(record proposals.Record) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return put(tx, proposalsBucket, heightKey(record.CLHeight, []byte(record.Validator)), record)
+	})
+}
+
+func (s *Store) AddAttempt(attempt proposals.Attempt) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		key := heightKey(attempt.CLHeight, heightKey(attempt.ELHeight), []byte(attempt.Validator))
+		return put(tx, attemptsBucket, key, attempt)
+	})
+}
+
+func (s *Store) AddError(event proposals.ErrorEvent) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(errorsBucket)
+		if b == nil {
+			return fmt.Errorf("bucket %s not found", errorsBucket)
+		}
+		seq, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		return put(tx, errorsBucket, timeKey(event.Time, seq), event)
+	})
+}
+
+func (s *Store) AddSignature(signature proposals.Signature) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return put(tx, signingBucket, heightKey(signature.Height, []byte(signature.Validator)), signature)
+	})
+}
+
+// Query returns matching proposal records, newest first.
+func (s *Store) Query(filter proposals.Filter) ([]proposals.Record, int, error) {
+	var matches []proposals.Record
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(proposalsBucket)
+		if b == nil {
+			return nil
+		}
+
+		c := b.Cursor()
+		var k, v []byte
+		if filter.ToHeight > 0 {
+			k, v = c.Seek(heightKey(filter.ToHeight + 1))
+			if k == nil {
+				k, v = c.Last()
+			} else {
+				k, v = c.Prev()
+			}
+		} else {
+			k, v = c.Last()
+		}
+
+		for ; k != nil; k, v = c.Prev() {
+			var record proposals.Record
+			if err := json.Unmarshal(v, &record); err != nil {
+				return fmt.Errorf("failed to decode proposal %x: %w", k, err)
+			}
+			if filter.FromHeight > 0 && record.CLHeight < filter.FromHeight {
+				break
+			}
+			if filter.Matches(record) {
+				matches = append(matches, record)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return proposals.Paginate(matches, filter.Offset, filter.Limit), len(matches), nil
+}
+
+// Signatures returns matching commit signatures, newest first.
+func (s *Store) Signatures(filter proposals.Filter) ([]proposals.Signature, int, error) {
+	var matches []proposals.Signature
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(signingBucket)
+		if b == nil {
+			return nil
+		}
+
+		c := b.Cursor()
+		var k, v []byte
+		if filter.ToHeight > 0 {
+			k, v = c.Seek(heightKey(filter.ToHeight + 1))
+			if k == nil {
+				k, v = c.Last()
+			} else {
+				k, v = c.Prev()
+			}
+		} else {
+			k, v = c.Last()
+		}
+
+		for ; k != nil; k, v = c.Prev() {
+			var signature proposals.Signature
+			if err := json.Unmarshal(v, &signature); err != nil {
+				return fmt.Errorf("failed to decode signature %x: %w", k, err)
+			}
+			if filter.FromHeight > 0 && signature.Height < filter.FromHeight {
+				break
+			}
+			if filter.MatchesSignature(signature) {
+				matches = append(matches, signature)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return proposals.PaginateSignatures(matches, filter.Offset, filter.Limit), len(matches), nil
+}
+
+// Attempts returns the EL match attempts recorded for a consensus height.
+func (s *Store) Attempts(clHeight int64) ([]proposals.Attempt, error) {
+	var attempts []proposals.Attempt
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(attemptsBucket)
+		if b == nil {
+			return nil
+		}
+
+		prefix := heightKey(clHeight)
+		c := b.Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			var attempt proposals.Attempt
+			if err := json.Unmarshal(v, &attempt); err != nil {
+				return fmt.Errorf("failed to decode attempt %x: %w", k, err)
+			}
+			attempts = append(attempts, attempt)
+		}
+		return nil
+	})
+
+	return attempts, err
+}
+
+// Errors returns the processing errors recorded in [from, to], oldest first.
+// A zero to means up to now.
+func (s *Store) Errors(from, to time.Time) ([]proposals.ErrorEvent, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+
+	var events []proposals.ErrorEvent
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(errorsBucket)
+		if b == nil {
+			return nil
+		}
+
+		c := b.Cursor()
+		k, v := c.First()
+		if !from.IsZero() {
+			k, v = c.Seek(timeKey(from, 0))
+		}
+		for ; k != nil; k, v = c.Next() {
+			var event proposals.ErrorEvent
+			if err := json.Unmarshal(v, &event); err != nil {
+				return fmt.Errorf("failed to decode error %x: %w", k, err)
+			}
+			if event.Time.After(to) {
+				break
+			}
+			events = append(events, event)
+		}
+		return nil
+	})
+
+	return events, err
+}
+
+// Retention limits how long each kind of entry is kept. Zero keeps entries
+// forever.
+type Retention struct {
+	Proposals  time.Duration
+	Attempts   time.Duration
+	Errors     time.Duration
+	Signatures time.Duration
+}
+
+// Prune deletes entries older than the retention policy and reports how many
+// were removed.
+func (s *Store) Prune(retention Retention, now time.Time) (int, error) {
+	removed := 0
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		if retention.Proposals > 0 {
+			cutoff := now.Add(-retention.Proposals)
+			n, err := pruneBucket(tx, proposalsBucket, func(v []byte) (bool, error) {
+				var record proposals.Record
+				if err := json.Unmarshal(v, &record); err != nil {
+					return false, err
+				}
+				return record.ObservedAt.Before(cutoff), nil
+			})
+			if err != nil {
+				return err
+			}
+			removed += n
+		}
+
+		if retention.Attempts > 0 {
+			cutoff := now.Add(-retention.Attempts)
+			n, err := pruneBucket(tx, attemptsBucket, func(v []byte) (bool, error) {
+				var attempt proposals.Attempt
+				if err := json.Unmarshal(v, &attempt); err != nil {
+					return false, err
+				}
+				return attempt.Time.Before(cutoff), nil
+			})
+			if err != nil {
+				return err
+			}
+			removed += n
+		}
+
+		if retention.Signatures > 0 {
+			cutoff := now.Add(-retention.Signatures)
+			n, err := pruneBucket(tx, signingBucket, func(v []byte) (bool, error) {
+				var signature proposals.Signature
+				if err := json.Unmarshal(v, &signature); err != nil {
+					return false, err
+				}
+				return signature.Time.Before(cutoff), nil
+			})
+			if err != nil {
+				return err
+			}
+			removed += n
+		}
+
+		if retention.Errors > 0 {
+			// Error keys are ordered by time, so everything before the cutoff
+			// key can go without decoding.
+			b := tx.Bucket(errorsBucket)
+			if b == nil {
+				return nil
+			}
+			cutoff := timeKey(now.Add(-retention.Errors), 0)
+			var stale [][]byte
+			c := b.Cursor()
+			for k, _ := c.First(); k != nil && bytes.Compare(k, cutoff) < 0; k, _ = c.Next() {
+				stale = append(stale, append([]byte(nil), k...))
+			}
+			for _, k := range stale {
+				if err := b.Delete(k); err != nil {
+					return err
+				}
+			}
+			removed += len(stale)
+		}
+
+		return nil
+	})
+
+	return removed, err
+}
+
+func pruneBucket(tx *bolt.Tx, bucket []byte, expired func(v []byte) (bool, error)) (int, error) {
+	b := tx.Bucket(bucket)
+	if b == nil {
+		return 0, nil
+	}
+
+	var stale [][]byte
+	err := b.ForEach(func(k, v []byte) error {
+		old, err := expired(v)
+		if err != nil {
+			return err
+		}
+		if old {
+			stale = append(stale, append([]byte(nil), k...))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, k := range stale {
+		if err := b.Delete(k); err != nil {
+			return 0, err
+		}
+	}
+	return len(stale), nil
+}
+
+// StartPruning applies the retention policy every interval until ctx is done.
+func (s *Store) StartPruning(ctx context.Context, retention Retention, interval time.Duration, log *logger.Logger) {
+	if retention == (Retention{}) {
+		return
+	}
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				removed, err := s.Prune(retention, time.Now())
+				if err != nil {
+					log.WriteJSONLog(logger.LevelError, "Failed to prune store", nil, err)
+				} else if removed > 0 {
+					log.WriteJSONLog(logger.LevelInfo, "Pruned store", map[string]interface{}{
+						"removed": removed,
+					}, nil)
+				}
+				time.Sleep(interval)
+			}
+		}
+	}()
+}
@@ -0,0 +1,158 @@
+package store
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cosmos-evm-exporter/internal/proposals"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "exporter.db"), false)
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestStoreQuery(t *testing.T) {
+	s := openTestStore(t)
+
+	for height := int64(100); height < 110; height++ {
+		status := proposals.StatusConfirmed
+		if height%4 == 0 {
+			status = proposals.StatusMissed
+		}
+		if err := s.Add(proposals.Record{Validator: "val1", CLHeight: height, Status: status}); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	// Replacing a record must not add a new one
+	s.Add(proposals.Record{Validator: "val1", CLHeight: 105, Status: proposals.StatusMissed})
+
+	tests := []struct {
+		name      string
+		filter    proposals.Filter
+		wantTotal int
+		wantFirst int64
+	}{
+		{name: "all", filter: proposals.Filter{}, wantTotal: 10, wantFirst: 109},
+		{name: "height range", filter: proposals.Filter{FromHeight: 102, ToHeight: 106}, wantTotal: 5, wantFirst: 106},
+		{name: "upper bound past end", filter: proposals.Filter{ToHeight: 500}, wantTotal: 10, wantFirst: 109},
+		{name: "missed", filter: proposals.Filter{Status: proposals.StatusMissed}, wantTotal: 4, wantFirst: 108},
+		{name: "page", filter: proposals.Filter{Offset: 3, Limit: 2}, wantTotal: 10, wantFirst: 106},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, total, err := s.Query(tt.filter)
+			if err != nil {
+				t.Fatalf("Query failed: %v", err)
+			}
+			if total != tt.wantTotal {
+				t.Errorf("Expected total %d, got %d", tt.wantTotal, total)
+			}
+			if len(records) == 0 || records[0].CLHeight != tt.wantFirst {
+				t.Errorf("Expected first height %d, got %+v", tt.wantFirst, records)
+			}
+		})
+	}
+}
+
+func TestStoreAttemptsAndErrors(t *testing.T) {
+	s := openTestStore(t)
+	now := time.Now().UTC()
+
+	for el := int64(48); el <= 52; el++ {
+		s.AddAttempt(proposals.Attempt{Validator: "val1", CLHeight: 100, ELHeight: el, Matched: el == 50, Time: now})
+	}
+	s.AddAttempt(proposals.Attempt{Validator: "val1", CLHeight: 101, ELHeight: 51, Time: now})
+
+	attempts, err := s.Attempts(100)
+	if err != nil {
+		t.Fatalf("Attempts failed: %v", err)
+	}
+	if len(attempts) != 5 {
+		t.Fatalf("Expected 5 attempts for height 100, got %d", len(attempts))
+	}
+	if attempts[0].ELHeight != 48 || !attempts[2].Matched {
+		t.Errorf("Unexpected attempts: %+v", attempts)
+	}
+
+	s.AddError(proposals.ErrorEvent{Component: "rpc", Message: "Failed to get block", Error: "timeout", Time: now.Add(-time.Hour)})
+	s.AddError(proposals.ErrorEvent{Component: "rpc", Message: "Failed to get block", Error: "timeout", Time: now})
+
+	events, err := s.Errors(time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("Errors failed: %v", err)
+	}
+	if len(events) != 2 {
+		t.Errorf("Expected 2 errors, got %d", len(events))
+	}
+
+	events, _ = s.Errors(now.Add(-time.Minute), time.Time{})
+	if len(events) != 1 {
+		t.Errorf("Expected 1 recent error, got %d", len(events))
+	}
+}
+
+func TestStorePrune(t *testing.T) {
+	s := openTestStore(t)
+	now := time.Now().UTC()
+	old := now.Add(-48 * time.Hour)
+
+	s.Add(proposals.Record{Validator: "val1", CLHeight: 1, ObservedAt: old})
+	s.Add(proposals.Record{Validator: "val1", CLHeight: 2, ObservedAt: now})
+	s.AddAttempt(proposals.Attempt{CLHeight: 1, ELHeight: 1, Time: old})
+	s.AddError(proposals.ErrorEvent{Error: "old", Time: old})
+	s.AddError(proposals.ErrorEvent{Error: "new", Time: now})
+
+	removed, err := s.Prune(Retention{
+		Proposals: 24 * time.Hour,
+		Attempts:  24 * time.Hour,
+		Errors:    24 * time.Hour,
+	}, now)
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if removed != 3 {
+		t.Errorf("Expected 3 entries removed, got %d", removed)
+	}
+
+	records, _, _ := s.Query(proposals.Filter{})
+	if len(records) != 1 || records[0].CLHeight != 2 {
+		t.Errorf("Expected only the recent proposal to remain, got %+v", records)
+	}
+}
+
+func TestStoreSignatures(t *testing.T) {
+	s := openTestStore(t)
+	now := time.Now().UTC()
+
+	for height := int64(100); height < 110; height++ {
+		s.AddSignature(proposals.Signature{Validator: "val1", Height: height, Signed: height != 105, Time: now})
+	}
+	s.AddSignature(proposals.Signature{Validator: "val2", Height: 105, Signed: true, Time: now})
+
+	signatures, total, err := s.Signatures(proposals.Filter{Validator: "val1", FromHeight: 104, ToHeight: 106})
+	if err != nil {
+		t.Fatalf("Signatures failed: %v", err)
+	}
+	if total != 3 || signatures[0].Height != 106 {
+		t.Fatalf("Expected heights 106..104, got %+v", signatures)
+	}
+	if signatures[1].Signed {
+		t.Errorf("Expected height 105 to be missed")
+	}
+
+	removed, err := s.Prune(Retention{Signatures: time.Hour}, now.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if removed != 11 {
+		t.Errorf("Expected 11 signatures removed, got %d", removed)
+	}
+}
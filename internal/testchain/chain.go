@@ -0,0 +1,326 @@
+package testchain
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// fillerBlocks is how many execution blocks exist before the one of Start,
+// enough for the exporter to scan behind its first proposal.
+const fillerBlocks = 20
+
+// Message types framing consensus transactions, like the chain does.
+const (
+	msgTypeExecutionPayload = 1
+	msgTypeDeposits         = 2
+)
+
+// depositsTx is an empty deposits transaction, carried by blocks that commit
+// to no execution block without being empty.
+var depositsTx = frame(msgTypeDeposits, []byte("[]"))
+
+// genesisTime is the time of the block at Start.
+var genesisTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// elBlock is an execution block and the state of its scripted faults.
+type elBlock struct {
+	original    *types.Block
+	replacement *types.Block // Served after a reorg, nil without one
+	reorgAfter  int
+	faults      []Fault
+	served      int // Times the original was served
+	requests    int
+}
+
+// Chain serves a Scenario over a CometBFT RPC and an EVM JSON-RPC server.
+type Chain struct {
+	scenario   Scenario
+	validators []string
+
+	cl *httptest.Server
+	el *httptest.Server
+
+	mu          sync.Mutex
+	tip         int64
+	elHeights   map[int64]uint64 // Execution block committed by each height
+	elHeads     map[int64]uint64 // Execution head once each height is produced
+	elBlocks    map[uint64]*elBlock
+	clRequests  map[int64]int
+	requested   map[int64]chan struct{}
+	subscribers map[chan int64]bool
+	closed      chan struct{}
+}
+
+// NewChain builds the blocks of scenario and starts its servers. Only the
+// block at Start is produced; later ones are produced as they are requested.
+func NewChain(scenario Scenario) *Chain {
+	c := &Chain{
+		scenario:    scenario,
+		tip:         scenario.Start,
+		elHeights:   map[int64]uint64{},
+		elHeads:     map[int64]uint64{},
+		elBlocks:    map[uint64]*elBlock{},
+		clRequests:  map[int64]int{},
+		requested:   map[int64]chan struct{}{},
+		subscribers: map[chan int64]bool{},
+		closed:      make(chan struct{}),
+	}
+	c.build()
+
+	c.cl = httptest.NewServer(c.cometHandler())
+	c.el = httptest.NewServer(http.HandlerFunc(c.handleEVM))
+	return c
+}
+
+// build creates the execution blocks, numbering them from Start - Gap and
+// skipping the heights that commit to none.
+func (c *Chain) build() {
+	seen := map[string]bool{}
+	for _, h := range c.scenario.Heights {
+		if !seen[h.Proposer] {
+			seen[h.Proposer] = true
+			c.validators = append(c.validators, h.Proposer)
+		}
+	}
+	sort.Strings(c.validators)
+
+	first := uint64(c.scenario.Start - c.scenario.Gap)
+	var parent common.Hash
+	number := uint64(1)
+	if first > fillerBlocks {
+		number = first - fillerBlocks
+	}
+	for ; number < first; number++ {
+		block := newELBlock(number, parent, common.Address{}, 0, c.blockTime(c.scenario.Start-int64(first-number)), "testchain")
+		c.elBlocks[number] = &elBlock{original: block}
+		parent = block.Hash()
+	}
+
+	head := first - 1
+	for i, h := range c.scenario.Heights {
+		height := c.scenario.Start + int64(i)
+		if !h.Empty && !h.NoELBlock {
+			block := newELBlock(number, parent, Coinbase(h.Proposer), h.ELTxs, c.blockTime(height), "testchain")
+			eb := &elBlock{original: block, faults: h.ELFaults}
+			if h.Reorg {
+				eb.replacement = newELBlock(number, parent, ReorgCoinbase, h.ELTxs, c.blockTime(height), "testchain-reorg")
+				eb.reorgAfter = h.ReorgAfter
+			}
+			c.elBlocks[number] = eb
+			c.elHeights[height] = number
+			head = number
+			parent = block.Hash()
+			number++
+		}
+		c.elHeads[height] = head
+	}
+}
+
+func newELBlock(number uint64, parent common.Hash, coinbase common.Address, txCount int, at time.Time, extra string) *types.Block {
+	txs := make([]*types.Transaction, txCount)
+	for i := range txs {
+		to := common.BigToAddress(big.NewInt(int64(i + 1)))
+		txs[i] = types.NewTx(&types.LegacyTx{Nonce: number<<16 + uint64(i), To: &to, Gas: 21000, GasPrice: big.NewInt(1e9)})
+	}
+	header := &types.Header{
+		ParentHash: parent,
+		Coinbase:   coinbase,
+		Root:       crypto.Keccak256Hash([]byte(extra), new(big.Int).SetUint64(number).Bytes()),
+		Number:     new(big.Int).SetUint64(number),
+		Difficulty: big.NewInt(0),
+		GasLimit:   30000000,
+		GasUsed:    uint64(txCount) * 21000,
+		Time:       uint64(at.Unix()),
+		Extra:      []byte(extra),
+		BaseFee:    big.NewInt(1e9),
+	}
+	return types.NewBlock(header, &types.Body{Transactions: txs}, nil, trie.NewStackTrie(nil))
+}
+
+func (c *Chain) blockTime(height int64) time.Time {
+	return genesisTime.Add(time.Duration(height-c.scenario.Start) * c.scenario.blockInterval())
+}
+
+// RPCEndpoint returns the URL of the CometBFT RPC, for rpc_endpoint.
+func (c *Chain) RPCEndpoint() string {
+	return c.cl.URL
+}
+
+// ETHEndpoint returns the URL of the EVM JSON-RPC, for eth_endpoint.
+func (c *Chain) ETHEndpoint() string {
+	return c.el.URL
+}
+
+// ELHeight returns the number of the execution block committed by the
+// consensus block at height, if it committed to one.
+func (c *Chain) ELHeight(height int64) (uint64, bool) {
+	number, ok := c.elHeights[height]
+	return number, ok
+}
+
+// ELBlock returns the execution block the chain currently serves at number.
+func (c *Chain) ELBlock(number uint64) *types.Block {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if eb, ok := c.elBlocks[number]; ok {
+		return eb.current()
+	}
+	return nil
+}
+
+// Tip returns the latest produced consensus height.
+func (c *Chain) Tip() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tip
+}
+
+// Advance produces the heights up to height, capped at the end of the
+// scenario, and announces them to websocket subscribers.
+func (c *Chain) Advance(height int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.advance(height)
+}
+
+func (c *Chain) advance(height int64) {
+	if end := c.scenario.End(); height > end {
+		height = end
+	}
+	for c.tip < height {
+		c.tip++
+		for subscriber := range c.subscribers {
+			subscriber <- c.tip
+		}
+	}
+}
+
+// WaitForBlock waits until the block at height has been requested. Since
+// the exporter moves to a height once it is done with the previous one,
+// waiting for End()+1 waits for the whole scenario to be processed.
+func (c *Chain) WaitForBlock(height int64, timeout time.Duration) bool {
+	select {
+	case <-c.requestedChan(height):
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+func (c *Chain) requestedChan(height int64) chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch, ok := c.requested[height]
+	if !ok {
+		ch = make(chan struct{})
+		c.requested[height] = ch
+	}
+	return ch
+}
+
+// Close stops both servers and ends websocket subscriptions.
+func (c *Chain) Close() {
+	close(c.closed)
+	c.cl.Close()
+	c.el.Close()
+}
+
+// current returns the block served at the block's number, after a reorg
+// when one is due.
+func (eb *elBlock) current() *types.Block {
+	if eb.replacement != nil && eb.served >= eb.reorgAfter {
+		return eb.replacement
+	}
+	return eb.original
+}
+
+// elHead returns the number of the latest execution block.
+func (c *Chain) elHead() uint64 {
+	return c.elHeads[c.tip]
+}
+
+// height returns the script of a consensus height.
+func (c *Chain) height(height int64) (Height, bool) {
+	if height < c.scenario.Start || height > c.scenario.End() {
+		return Height{}, false
+	}
+	return c.scenario.Heights[height-c.scenario.Start], true
+}
+
+// nextFault consumes the next scripted fault of faults, given the number of
+// requests already served.
+func nextFault(faults []Fault, requests int) Fault {
+	if requests < len(faults) {
+		return faults[requests]
+	}
+	return FaultNone
+}
+
+// payloadTx frames the execution payload of block as a consensus
+// transaction.
+func payloadTx(block *types.Block) ([]byte, error) {
+	payload, err := json.Marshal(engine.BlockToExecutableData(block, nil, nil).ExecutionPayload)
+	if err != nil {
+		return nil, err
+	}
+	return frame(msgTypeExecutionPayload, payload), nil
+}
+
+// frame prefixes payload with its message type and length.
+func frame(msgType uint32, payload []byte) []byte {
+	tx := make([]byte, 8+len(payload))
+	binary.BigEndian.PutUint32(tx[0:4], msgType)
+	binary.BigEndian.PutUint32(tx[4:8], uint32(len(payload)))
+	copy(tx[8:], payload)
+	return tx
+}
+
+// cometHash returns a deterministic CometBFT style hash of the parts.
+func cometHash(parts ...interface{}) string {
+	sum := sha256.Sum256([]byte(fmt.Sprint(parts...)))
+	return strings.ToUpper(fmt.Sprintf("%x", sum))
+}
+
+// inject writes the response of a fault other than FaultNone: errBody for
+// FaultError and half of the real body for FaultMalformed.
+func (c *Chain) inject(w http.ResponseWriter, fault Fault, body, errBody []byte) {
+	switch fault {
+	case FaultError:
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write(errBody)
+	case FaultMalformed:
+		w.Write(body[:len(body)/2])
+	case FaultTimeout:
+		select {
+		case <-time.After(c.scenario.TimeoutDelay):
+		case <-c.closed:
+		}
+		// Drop the connection in the middle of the response. Without any bytes
+		// sent, the client would transparently retry on a new connection.
+		if hijacker, ok := w.(http.Hijacker); ok {
+			if conn, buf, err := hijacker.Hijack(); err == nil {
+				fmt.Fprintf(buf, "HTTP/1.1 200 OK\r\nContent-Type: application/json\r\nContent-Length: %d\r\n\r\n", len(body))
+				buf.Write(body[:len(body)/2])
+				buf.Flush()
+				conn.Close()
+				return
+			}
+		}
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}
+}
@@ -0,0 +1,200 @@
+package testchain
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/gorilla/websocket"
+)
+
+func getJSON(t *testing.T, url string, v interface{}) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", url, err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatalf("Failed to decode %s: %v", url, err)
+	}
+}
+
+type blockResponse struct {
+	Result *blockResult `json:"result"`
+	Error  *rpcError    `json:"error"`
+}
+
+func TestChainProducesRequestedBlocks(t *testing.T) {
+	chain := NewChain(Scenario{
+		Start: 100,
+		Gap:   10,
+		Lead:  1,
+		Heights: []Height{
+			{Proposer: "AAAA"},
+			{Proposer: "BBBB", Empty: true, Absent: []string{"AAAA"}},
+			{Proposer: "AAAA", ELTxs: 2},
+		},
+	})
+	defer chain.Close()
+
+	var status struct {
+		Result struct {
+			SyncInfo struct {
+				LatestBlockHeight string `json:"latest_block_height"`
+			} `json:"sync_info"`
+		} `json:"result"`
+	}
+	getJSON(t, chain.RPCEndpoint()+"/status", &status)
+	if status.Result.SyncInfo.LatestBlockHeight != "100" {
+		t.Errorf("Expected tip 100 before any request, got %s", status.Result.SyncInfo.LatestBlockHeight)
+	}
+
+	var block blockResponse
+	getJSON(t, chain.RPCEndpoint()+"/block?height=101", &block)
+	if block.Result == nil || block.Result.Block.Header.ProposerAddress != "BBBB" || len(block.Result.Block.Data.Txs) != 0 {
+		t.Fatalf("Expected empty block 101 from BBBB, got %+v", block)
+	}
+	if chain.Tip() != 102 {
+		t.Errorf("Expected the request to produce up to 102, got %d", chain.Tip())
+	}
+	for _, sig := range block.Result.Block.LastCommit.Signatures {
+		if signed := sig.BlockIDFlag == blockIDFlagCommit; signed == (sig.ValidatorAddress == "AAAA") {
+			t.Errorf("Expected only AAAA to be absent, got %+v", sig)
+		}
+	}
+
+	getJSON(t, chain.RPCEndpoint()+"/block?height=103", &block)
+	if block.Error == nil {
+		t.Errorf("Expected an error past the end of the scenario")
+	}
+
+	// The empty block commits to no execution block, so 102 builds on 100's
+	number, ok := chain.ELHeight(102)
+	if first, _ := chain.ELHeight(100); !ok || number != first+1 {
+		t.Errorf("Expected 102 to commit to EL block %d, got %d", first+1, number)
+	}
+
+	client, err := ethclient.Dial(chain.ETHEndpoint())
+	if err != nil {
+		t.Fatalf("Failed to dial EL: %v", err)
+	}
+	head, err := client.BlockNumber(context.Background())
+	if err != nil || head != number {
+		t.Fatalf("Expected EL head %d, got %d (%v)", number, head, err)
+	}
+	elBlock, err := client.BlockByNumber(context.Background(), new(big.Int).SetUint64(number))
+	if err != nil {
+		t.Fatalf("BlockByNumber() error = %v", err)
+	}
+	if elBlock.Coinbase() != Coinbase("AAAA") || len(elBlock.Transactions()) != 2 || elBlock.Hash() != chain.ELBlock(number).Hash() {
+		t.Errorf("Expected AAAA's block with 2 transactions, got coinbase %s with %d", elBlock.Coinbase().Hex(), len(elBlock.Transactions()))
+	}
+	if _, err := client.BlockByNumber(context.Background(), new(big.Int).SetUint64(number+1)); err == nil {
+		t.Errorf("Expected no block past the head")
+	}
+}
+
+func TestChainFaultsAndReorgs(t *testing.T) {
+	chain := NewChain(Scenario{
+		Start: 50,
+		Heights: []Height{
+			{Proposer: "AAAA", CLFaults: []Fault{FaultError, FaultMalformed, FaultTimeout}, ELFaults: []Fault{FaultError}},
+			{Proposer: "AAAA", Reorg: true, ReorgAfter: 1},
+		},
+	})
+	defer chain.Close()
+
+	url := chain.RPCEndpoint() + "/block?height=50"
+	for i, check := range []func(*http.Response, error) bool{
+		func(resp *http.Response, err error) bool {
+			return err == nil && resp.StatusCode == http.StatusInternalServerError
+		},
+		func(resp *http.Response, err error) bool {
+			return err == nil && json.NewDecoder(resp.Body).Decode(&blockResponse{}) != nil
+		},
+		func(resp *http.Response, err error) bool {
+			return err != nil || json.NewDecoder(resp.Body).Decode(&blockResponse{}) != nil
+		},
+		func(resp *http.Response, err error) bool { return err == nil && resp.StatusCode == http.StatusOK },
+	} {
+		resp, err := http.Get(url)
+		if !check(resp, err) {
+			t.Errorf("Unexpected response %d: %v", i, err)
+		}
+		if err == nil {
+			resp.Body.Close()
+		}
+	}
+
+	client, err := ethclient.Dial(chain.ETHEndpoint())
+	if err != nil {
+		t.Fatalf("Failed to dial EL: %v", err)
+	}
+	first, _ := chain.ELHeight(50)
+	if _, err := client.BlockByNumber(context.Background(), new(big.Int).SetUint64(first)); err == nil {
+		t.Errorf("Expected the scripted EL failure")
+	}
+	if _, err := client.BlockByNumber(context.Background(), new(big.Int).SetUint64(first)); err != nil {
+		t.Errorf("Expected the block once the fault is consumed, got %v", err)
+	}
+
+	chain.Advance(51)
+	reorged, _ := chain.ELHeight(51)
+	for i, want := range []string{Coinbase("AAAA").Hex(), ReorgCoinbase.Hex(), ReorgCoinbase.Hex()} {
+		block, err := client.BlockByNumber(context.Background(), new(big.Int).SetUint64(reorged))
+		if err != nil || block.Coinbase().Hex() != want {
+			t.Errorf("Request %d: expected coinbase %s, got %v (%v)", i, want, block, err)
+		}
+	}
+}
+
+func TestChainWebsocket(t *testing.T) {
+	chain := NewChain(Scenario{
+		Start:   10,
+		Heights: []Height{{Proposer: "AAAA"}, {Proposer: "BBBB"}, {Proposer: "AAAA"}},
+	})
+	defer chain.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(chain.RPCEndpoint(), "http")+"/websocket", nil)
+	if err != nil {
+		t.Fatalf("Failed to dial websocket: %v", err)
+	}
+	defer conn.Close()
+
+	conn.WriteJSON(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "subscribe",
+		"params":  map[string]string{"query": "tm.event='NewBlock'"},
+	})
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var ack rpcResponse
+	if err := conn.ReadJSON(&ack); err != nil || ack.Error != nil {
+		t.Fatalf("Expected a subscription ack, got %+v (%v)", ack, err)
+	}
+
+	chain.Advance(12)
+	for _, want := range []string{"11", "12"} {
+		var event struct {
+			Result struct {
+				Data struct {
+					Value struct {
+						Block block `json:"block"`
+					} `json:"value"`
+				} `json:"data"`
+			} `json:"result"`
+		}
+		if err := conn.ReadJSON(&event); err != nil {
+			t.Fatalf("Failed to read event: %v", err)
+		}
+		if got := event.Result.Data.Value.Block.Header.Height; got != want {
+			t.Errorf("Expected NewBlock %s, got %s", want, got)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+package testchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// CometBFT wire types, limited to what the exporter and typical clients read.
+type (
+	rpcResponse struct {
+		JSONRPC string      `json:"jsonrpc"`
+		ID      interface{} `json:"id"`
+		Result  interface{} `json:"result,omitempty"`
+		Error   *rpcError   `json:"error,omitempty"`
+	}
+
+	rpcError struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    string `json:"data,omitempty"`
+	}
+
+	blockID struct {
+		Hash  string `json:"hash"`
+		Parts struct {
+			Total int    `json:"total"`
+			Hash  string `json:"hash"`
+		} `json:"parts"`
+	}
+
+	blockResult struct {
+		BlockID blockID `json:"block_id"`
+		Block   block   `json:"block"`
+	}
+
+	block struct {
+		Header struct {
+			Version struct {
+				Block string `json:"block"`
+				App   string `json:"app"`
+			} `json:"version"`
+			ChainID         string    `json:"chain_id"`
+			Height          string    `json:"height"`
+			Time            time.Time `json:"time"`
+			LastBlockID     blockID   `json:"last_block_id"`
+			DataHash        string    `json:"data_hash"`
+			ValidatorsHash  string    `json:"validators_hash"`
+			AppHash         string    `json:"app_hash"`
+			ProposerAddress string    `json:"proposer_address"`
+		} `json:"header"`
+		Data struct {
+			Txs []string `json:"txs"`
+		} `json:"data"`
+		Evidence struct {
+			Evidence []interface{} `json:"evidence"`
+		} `json:"evidence"`
+		LastCommit commit `json:"last_commit"`
+	}
+
+	commit struct {
+		Height     string      `json:"height"`
+		Round      int         `json:"round"`
+		BlockID    blockID     `json:"block_id"`
+		Signatures []commitSig `json:"signatures"`
+	}
+
+	commitSig struct {
+		BlockIDFlag      int       `json:"block_id_flag"`
+		ValidatorAddress string    `json:"validator_address"`
+		Timestamp        time.Time `json:"timestamp"`
+		Signature        *string   `json:"signature"`
+	}
+
+	validator struct {
+		Address          string `json:"address"`
+		VotingPower      string `json:"voting_power"`
+		ProposerPriority string `json:"proposer_priority"`
+	}
+)
+
+// Commit signature flags.
+const (
+	blockIDFlagAbsent = 1
+	blockIDFlagCommit = 2
+)
+
+func (c *Chain) cometHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", c.handleStatus)
+	mux.HandleFunc("/block", c.handleBlock)
+	mux.HandleFunc("/validators", c.handleValidators)
+	mux.HandleFunc("/websocket", c.handleWebsocket)
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func errorResponse(id interface{}, data string) rpcResponse {
+	return rpcResponse{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: -32603, Message: "Internal error", Data: data}}
+}
+
+func (c *Chain) handleStatus(w http.ResponseWriter, r *http.Request) {
+	tip := c.Tip()
+	writeJSON(w, rpcResponse{JSONRPC: "2.0", ID: -1, Result: map[string]interface{}{
+		"node_info": map[string]string{
+			"id":      "testchain",
+			"network": c.scenario.chainID(),
+			"version": "0.38.12",
+			"moniker": "testchain",
+		},
+		"sync_info": map[string]interface{}{
+			"latest_block_hash":   cometHash("block", tip),
+			"latest_block_height": strconv.FormatInt(tip, 10),
+			"latest_block_time":   c.blockTime(tip),
+			"catching_up":         false,
+		},
+		"validator_info": map[string]string{
+			"address":      "",
+			"voting_power": "0",
+		},
+	}})
+}
+
+// handleBlock serves /block?height=N. Requesting a height produces it and
+// the Scenario.Lead heights after it.
+func (c *Chain) handleBlock(w http.ResponseWriter, r *http.Request) {
+	height, err := strconv.ParseInt(r.URL.Query().Get("height"), 10, 64)
+	if err != nil {
+		writeJSON(w, errorResponse(-1, "height must be an integer"))
+		return
+	}
+
+	requested := c.requestedChan(height)
+	c.mu.Lock()
+	select {
+	case <-requested:
+	default:
+		close(requested)
+	}
+	c.advance(height + c.scenario.Lead)
+	tip := c.tip
+	requests := c.clRequests[height]
+	c.clRequests[height]++
+	c.mu.Unlock()
+
+	script, ok := c.height(height)
+	if !ok || height > tip {
+		writeJSON(w, errorResponse(-1, fmt.Sprintf("height %d must be less than or equal to the current blockchain height %d", height, tip)))
+		return
+	}
+
+	result, err := c.block(height)
+	if err != nil {
+		writeJSON(w, errorResponse(-1, err.Error()))
+		return
+	}
+	body, _ := json.Marshal(rpcResponse{JSONRPC: "2.0", ID: -1, Result: result})
+
+	if fault := nextFault(script.CLFaults, requests); fault != FaultNone {
+		errBody, _ := json.Marshal(errorResponse(-1, "simulated failure"))
+		c.inject(w, fault, body, errBody)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// block builds the consensus block at a scripted height.
+func (c *Chain) block(height int64) (*blockResult, error) {
+	script, _ := c.height(height)
+
+	var b blockResult
+	b.BlockID.Hash = cometHash("block", height)
+	b.BlockID.Parts.Total = 1
+	b.BlockID.Parts.Hash = cometHash("parts", height)
+
+	header := &b.Block.Header
+	header.Version.Block = "11"
+	header.Version.App = "0"
+	header.ChainID = c.scenario.chainID()
+	header.Height = strconv.FormatInt(height, 10)
+	header.Time = c.blockTime(height)
+	header.LastBlockID.Hash = cometHash("block", height-1)
+	header.DataHash = cometHash("data", height)
+	header.ValidatorsHash = cometHash("validators")
+	header.AppHash = cometHash("app", height)
+	header.ProposerAddress = script.Proposer
+
+	b.Block.Data.Txs = []string{}
+	if number, ok := c.elHeights[height]; ok {
+		tx, err := payloadTx(c.elBlocks[number].original)
+		if err != nil {
+			return nil, err
+		}
+		b.Block.Data.Txs = append(b.Block.Data.Txs, base64.StdEncoding.EncodeToString(tx))
+	} else if !script.Empty {
+		b.Block.Data.Txs = append(b.Block.Data.Txs, base64.StdEncoding.EncodeToString(depositsTx))
+	}
+	b.Block.Evidence.Evidence = []interface{}{}
+
+	b.Block.LastCommit = commit{
+		Height:  strconv.FormatInt(height-1, 10),
+		BlockID: header.LastBlockID,
+	}
+	absent := map[string]bool{}
+	for _, address := range script.Absent {
+		absent[address] = true
+	}
+	for _, address := range c.validators {
+		// Absent validators keep their address so tests can tell whose
+		// signature is missing
+		sig := commitSig{BlockIDFlag: blockIDFlagAbsent, ValidatorAddress: address}
+		if !absent[address] {
+			signature := base64.StdEncoding.EncodeToString([]byte(cometHash("sig", address, height-1))[:64])
+			sig = commitSig{BlockIDFlag: blockIDFlagCommit, ValidatorAddress: address, Timestamp: header.Time, Signature: &signature}
+		}
+		b.Block.LastCommit.Signatures = append(b.Block.LastCommit.Signatures, sig)
+	}
+	return &b, nil
+}
+
+// handleValidators serves /validators: every proposer of the scenario, with
+// equal voting power.
+func (c *Chain) handleValidators(w http.ResponseWriter, r *http.Request) {
+	height := c.Tip()
+	if h, err := strconv.ParseInt(r.URL.Query().Get("height"), 10, 64); err == nil {
+		height = h
+	}
+
+	validators := []validator{}
+	for _, address := range c.validators {
+		validators = append(validators, validator{Address: address, VotingPower: "10", ProposerPriority: "0"})
+	}
+	writeJSON(w, rpcResponse{JSONRPC: "2.0", ID: -1, Result: map[string]interface{}{
+		"block_height": strconv.FormatInt(height, 10),
+		"validators":   validators,
+		"count":        strconv.Itoa(len(validators)),
+		"total":        strconv.Itoa(len(validators)),
+	}})
+}
+
+var upgrader = websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+
+// handleWebsocket serves the JSON-RPC websocket. A subscribe to a NewBlock
+// query receives an event for every block produced afterwards.
+func (c *Chain) handleWebsocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	var writeMu sync.Mutex
+	write := func(v interface{}) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		return conn.WriteJSON(v)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	for {
+		var request struct {
+			ID     interface{} `json:"id"`
+			Method string      `json:"method"`
+			Params struct {
+				Query string `json:"query"`
+			} `json:"params"`
+		}
+		if err := conn.ReadJSON(&request); err != nil {
+			return
+		}
+
+		if request.Method != "subscribe" || !strings.Contains(request.Params.Query, "NewBlock") {
+			write(rpcResponse{JSONRPC: "2.0", ID: request.ID, Error: &rpcError{Code: -32601, Message: "Method not found"}})
+			continue
+		}
+		write(rpcResponse{JSONRPC: "2.0", ID: request.ID, Result: map[string]interface{}{}})
+
+		events := make(chan int64, len(c.scenario.Heights)+1)
+		c.mu.Lock()
+		c.subscribers[events] = true
+		c.mu.Unlock()
+		go c.publish(events, request.ID, request.Params.Query, write, done)
+	}
+}
+
+// publish sends a NewBlock event for every height on events until the
+// connection or the chain closes.
+func (c *Chain) publish(events chan int64, id interface{}, query string, write func(interface{}) error, done chan struct{}) {
+	defer func() {
+		c.mu.Lock()
+		delete(c.subscribers, events)
+		c.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-c.closed:
+			return
+		case height := <-events:
+			b, err := c.block(height)
+			if err != nil {
+				continue
+			}
+			event := map[string]interface{}{
+				"query": query,
+				"data": map[string]interface{}{
+					"type":  "tendermint/event/NewBlock",
+					"value": map[string]interface{}{"block": b.Block, "block_id": b.BlockID},
+				},
+			}
+			if err := write(rpcResponse{JSONRPC: "2.0", ID: id, Result: event}); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package testchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type evmRequest struct {
+	JSONRPC string            `json:"jsonrpc"`
+	ID      json.RawMessage   `json:"id"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+}
+
+// handleEVM serves the EVM JSON-RPC methods the exporter uses to follow
+// blocks. Other methods, like eth_getBlockReceipts, answer method not found.
+func (c *Chain) handleEVM(w http.ResponseWriter, r *http.Request) {
+	var request evmRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeJSON(w, rpcResponse{JSONRPC: "2.0", Error: &rpcError{Code: -32700, Message: "parse error"}})
+		return
+	}
+	id := request.ID
+
+	switch request.Method {
+	case "eth_blockNumber":
+		c.mu.Lock()
+		head := c.elHead()
+		c.mu.Unlock()
+		writeJSON(w, rpcResponse{JSONRPC: "2.0", ID: id, Result: hexutil.Uint64(head)})
+	case "eth_chainId":
+		writeJSON(w, rpcResponse{JSONRPC: "2.0", ID: id, Result: hexutil.Uint64(c.scenario.evmChainID())})
+	case "eth_getBalance":
+		writeJSON(w, rpcResponse{JSONRPC: "2.0", ID: id, Result: "0x0"})
+	case "eth_getBlockByNumber":
+		c.handleGetBlock(w, request)
+	default:
+		writeJSON(w, rpcResponse{JSONRPC: "2.0", ID: id, Error: &rpcError{
+			Code:    -32601,
+			Message: fmt.Sprintf("the method %s does not exist/is not available", request.Method),
+		}})
+	}
+}
+
+// handleGetBlock serves eth_getBlockByNumber, returning null for blocks past
+// the head and injecting the faults scripted for the block.
+func (c *Chain) handleGetBlock(w http.ResponseWriter, request evmRequest) {
+	var tag string
+	if len(request.Params) > 0 {
+		json.Unmarshal(request.Params[0], &tag)
+	}
+
+	c.mu.Lock()
+	head := c.elHead()
+	number := head
+	if tag != "latest" {
+		parsed, err := hexutil.DecodeUint64(tag)
+		if err != nil {
+			c.mu.Unlock()
+			writeJSON(w, rpcResponse{JSONRPC: "2.0", ID: request.ID, Error: &rpcError{Code: -32602, Message: "invalid block number"}})
+			return
+		}
+		number = parsed
+	}
+
+	eb, ok := c.elBlocks[number]
+	if !ok || number > head {
+		c.mu.Unlock()
+		writeJSON(w, json.RawMessage(fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"result":null}`, request.ID)))
+		return
+	}
+	fault := nextFault(eb.faults, eb.requests)
+	eb.requests++
+	block := eb.current()
+	if fault == FaultNone && block == eb.original {
+		eb.served++
+	}
+	c.mu.Unlock()
+
+	result, err := marshalBlock(block)
+	if err != nil {
+		writeJSON(w, rpcResponse{JSONRPC: "2.0", ID: request.ID, Error: &rpcError{Code: -32603, Message: err.Error()}})
+		return
+	}
+	body := []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"result":%s}`, request.ID, result))
+
+	if fault != FaultNone {
+		errBody, _ := json.Marshal(rpcResponse{JSONRPC: "2.0", ID: request.ID, Error: &rpcError{Code: -32603, Message: "simulated failure"}})
+		c.inject(w, fault, body, errBody)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// marshalBlock encodes block like eth_getBlockByNumber with full
+// transactions.
+func marshalBlock(block *types.Block) ([]byte, error) {
+	header, err := json.Marshal(block.Header())
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(header, &fields); err != nil {
+		return nil, err
+	}
+	fields["transactions"] = block.Transactions()
+	fields["uncles"] = []interface{}{}
+	fields["size"] = hexutil.Uint64(block.Size())
+	return json.Marshal(fields)
+}
@@ -0,0 +1,95 @@
+// Package testchain simulates a CometBFT consensus node and the EVM
+// execution client behind it, so the exporter can be tested end to end
+// without a network. A Scenario scripts who proposes every height, the
+// execution block each proposal commits to and the faults the servers
+// inject along the way.
+package testchain
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Fault is a failure injected into one response.
+type Fault int
+
+const (
+	FaultNone      Fault = iota
+	FaultError           // An error response
+	FaultMalformed       // A truncated response body
+	FaultTimeout         // The connection drops mid-response after Scenario.TimeoutDelay
+)
+
+// Height scripts one consensus height.
+type Height struct {
+	Proposer  string // Hex consensus address of the proposer
+	Empty     bool   // No transactions, and so no execution block
+	NoELBlock bool   // The block has transactions but commits to no execution block, widening the gap
+	ELTxs     int    // Transactions in the execution block
+
+	// Reorg replaces the execution block with a competing one from another
+	// coinbase once it has been served ReorgAfter times.
+	Reorg      bool
+	ReorgAfter int
+
+	Absent []string // Validators absent from the last commit carried by the block
+
+	CLFaults []Fault // Faults of successive /block requests for the height
+	ELFaults []Fault // Faults of successive requests for the execution block
+}
+
+// Scenario is the script of a simulated chain.
+type Scenario struct {
+	Start int64 // First consensus height
+	Gap   int64 // Consensus height minus execution height at Start
+
+	// Lead is how many heights past the last requested block are produced.
+	// At zero the exporter follows the tip, where the blocks after the one it
+	// processes don't exist yet.
+	Lead int64
+
+	ChainID       string        // Consensus chain ID, "testchain-1" by default
+	EVMChainID    int64         // Execution chain ID, 1 by default
+	BlockInterval time.Duration // Time between blocks, one second by default
+	TimeoutDelay  time.Duration // Delay before a FaultTimeout drops the connection
+
+	Heights []Height
+}
+
+// Coinbase returns the execution coinbase of proposer. Every proposer builds
+// with its own coinbase, so the exporter's evm_address is Coinbase of its
+// target_validator.
+func Coinbase(proposer string) common.Address {
+	return common.BytesToAddress(crypto.Keccak256([]byte(proposer))[12:])
+}
+
+// ReorgCoinbase is the coinbase of the blocks replacing reorged ones.
+var ReorgCoinbase = Coinbase("reorg")
+
+func (s Scenario) chainID() string {
+	if s.ChainID == "" {
+		return "testchain-1"
+	}
+	return s.ChainID
+}
+
+func (s Scenario) evmChainID() int64 {
+	if s.EVMChainID == 0 {
+		return 1
+	}
+	return s.EVMChainID
+}
+
+func (s Scenario) blockInterval() time.Duration {
+	if s.BlockInterval == 0 {
+		return time.Second
+	}
+	return s.BlockInterval
+}
+
+// End returns the last scripted consensus height.
+func (s Scenario) End() int64 {
+	return s.Start + int64(len(s.Heights)) - 1
+}
@@ -7,8 +7,20 @@ import (
 	"cosmos-evm-exporter/internal/config"
 	"cosmos-evm-exporter/internal/logger"
 	"cosmos-evm-exporter/internal/metrics"
+	"cosmos-evm-exporter/internal/testchain"
 )
 
+// newChain starts a simulated chain for the benchmarks that query the nodes.
+func newChain(b *testing.B) *testchain.Chain {
+	chain := testchain.NewChain(testchain.Scenario{
+		Start:   7368349,
+		Gap:     100,
+		Heights: []testchain.Height{{Proposer: "ABCD", ELTxs: 10}},
+	})
+	b.Cleanup(chain.Close)
+	return chain
+}
+
 func BenchmarkProcessBlock(b *testing.B) {
 	metrics := metrics.NewBlockMetrics()
 	testLogger := logger.NewLogger(&logger.Config{
@@ -16,8 +28,11 @@ func BenchmarkProcessBlock(b *testing.B) {
 		LogFile:      "test.log",
 	})
 
+	chain := newChain(b)
 	config := &config.Config{
-		EVMAddress:      "0x1234",
+		RPCEndpoint:     chain.RPCEndpoint(),
+		ETHEndpoint:     chain.ETHEndpoint(),
+		EVMAddress:      testchain.Coinbase("ABCD").Hex(),
 		TargetValidator: "ABCD",
 	}
 
@@ -43,9 +58,10 @@ func BenchmarkGetCurrentGap(b *testing.B) {
 		LogFile:      "test.log",
 	})
 
+	chain := newChain(b)
 	config := &config.Config{
-		RPCEndpoint: "http://localhost:26657",
-		ETHEndpoint: "http://localhost:8545",
+		RPCEndpoint: chain.RPCEndpoint(),
+		ETHEndpoint: chain.ETHEndpoint(),
 	}
 
 	processor, err := blockchain.NewBlockProcessor(config, metrics, testLogger)
@@ -66,8 +82,10 @@ func BenchmarkGetCurrentHeight(b *testing.B) {
 		LogFile:      "test.log",
 	})
 
+	chain := newChain(b)
 	config := &config.Config{
-		RPCEndpoint: "http://localhost:26657",
+		RPCEndpoint: chain.RPCEndpoint(),
+		ETHEndpoint: chain.ETHEndpoint(),
 	}
 
 	processor, err := blockchain.NewBlockProcessor(config, metrics, testLogger)
@@ -88,8 +106,10 @@ func BenchmarkGetCurrentELHeight(b *testing.B) {
 		LogFile:      "test.log",
 	})
 
+	chain := newChain(b)
 	config := &config.Config{
-		ETHEndpoint: "http://localhost:8545",
+		RPCEndpoint: chain.RPCEndpoint(),
+		ETHEndpoint: chain.ETHEndpoint(),
 	}
 
 	processor, err := blockchain.NewBlockProcessor(config, metrics, testLogger)